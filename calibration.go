@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Calibration adjusts how a Mei figure sits on the game canvas
+type Calibration struct {
+	Scale   float64 `json:"scale,omitempty"`    // relative to the original figure's height, 0 means 1
+	OffsetX int     `json:"offset_x,omitempty"` // pixels, right is positive
+	OffsetY int     `json:"offset_y,omitempty"` // pixels, down is positive
+	Anchor  string  `json:"anchor,omitempty"`   // feet or head, "" means the character's default
+}
+
+// steps the calibration menu nudges by
+const (
+	scaleStep  = 0.05
+	offsetStep = 5
+)
+
+func (c Calibration) scale() float64 {
+	if c.Scale <= 0 {
+		return 1
+	}
+	return c.Scale
+}
+
+// Returns the line the figure is aligned on, falling back to the
+// character's entry in CharacterAnchors
+func (c Calibration) anchor(character string) string {
+	if c.Anchor != "" {
+		return c.Anchor
+	}
+	return anchorFor(character)
+}
+
+func (c Calibration) String() string {
+	return fmt.Sprintf("scale %.2f, x %+d, y %+d", c.scale(), c.OffsetX, c.OffsetY)
+}
+
+// Returns the config key of an outfit override: rena/v005
+func calibrationKey(character, variant string) string {
+	if variant == "" {
+		return character
+	}
+	return character + "/" + variant
+}
+
+// Returns the calibration for a character drawn in an outfit of source
+// (the character itself unless swapped), falling back to the character's
+// own and then to none
+func calibrationFor(calibrations map[string]Calibration, character, source, variant string) Calibration {
+	if c, ok := calibrations[calibrationKey(source, variant)]; ok {
+		return c
+	}
+	return calibrations[character]
+}
+
+// Returns the variant a fixed outfit selection names, or "" for the
+// Best Match and random selections
+func selectionVariant(selection string) string {
+	start := strings.LastIndex(selection, "(variant: ")
+	if start == -1 {
+		return ""
+	}
+	end := strings.Index(selection[start:], ")")
+	if end == -1 {
+		return ""
+	}
+	return selection[start+10 : start+end]
+}
@@ -469,4 +469,250 @@ var Characters = map[string]CharacterData{
 	},
 	OutfitsAA: []Outfit{}, // Placeholder for Ace Attorney
 },
-}
\ No newline at end of file
+"kameda": {
+	DisplayName: "Kameda",
+	OutfitsMei: []Outfit{
+		{"Default", "v001"}, // Mei folder: haruhi
+	},
+	OutfitsAA: []Outfit{}, // Placeholder for Ace Attorney
+},
+"rina": {
+	DisplayName: "Rina",
+	OutfitsMei: []Outfit{
+		{"Default", "v001"}, // Mei folder: youhei
+	},
+	OutfitsAA: []Outfit{}, // Placeholder for Ace Attorney
+},
+"oko": {
+	DisplayName: "Oko",
+	OutfitsMei: []Outfit{
+		{"Default", "v001"}, // Mei folder: fuko
+	},
+	OutfitsAA: []Outfit{}, // Placeholder for Ace Attorney
+},
+"mo": {
+	DisplayName: "Mo",
+	OutfitsMei: []Outfit{
+		{"Default", "v001"}, // Mei folder: eua
+	},
+	OutfitsAA: []Outfit{}, // Placeholder for Ace Attorney
+},
+"mura": {
+	DisplayName: "Mura",
+	OutfitsMei: []Outfit{
+		{"Default", "v001"}, // Mei folder: eua
+	},
+	OutfitsAA: []Outfit{}, // Placeholder for Ace Attorney
+},
+}
+
+// content filter tags for every outfit above, keyed by outfit name:
+// category, season, age, rating, canonical
+var outfitTags = map[string]OutfitTags{
+	"40s":                            {"costume", "", "", "general", false},
+	"Adult":                          {"costume", "", "adult", "general", false},
+	"Adult (Casual)":                 {"costume", "", "adult", "general", false},
+	"Adventurer":                     {"costume", "", "", "general", false},
+	"Angel":                          {"costume", "", "", "general", false},
+	"Angel Mort":                     {"everyday", "", "", "general", true},
+	"Army":                           {"everyday", "", "", "general", true},
+	"Azure Swordswoman":              {"costume", "", "", "general", false},
+	"Baseball":                       {"everyday", "", "", "general", true},
+	"Black Dress":                    {"costume", "", "", "general", false},
+	"Black Maiden":                   {"costume", "", "", "general", false},
+	"Blazer":                         {"costume", "", "", "general", false},
+	"Borg Decker":                    {"costume", "", "", "general", false},
+	"Bunny":                          {"costume", "", "", "suggestive", false},
+	"Camping":                        {"costume", "", "", "general", false},
+	"Card Soldier":                   {"costume", "", "", "general", false},
+	"Casual":                         {"everyday", "", "", "general", true},
+	"Casual (GouSotsu Design)":       {"costume", "", "", "general", false},
+	"Cat":                            {"costume", "", "", "general", false},
+	"Cheerleader":                    {"costume", "", "", "general", false},
+	"Chef":                           {"costume", "", "", "general", false},
+	"Chiester Sister":                {"crossover", "", "", "general", false},
+	"Child":                          {"costume", "", "child", "general", false},
+	"Chinese New Year":               {"seasonal", "new year", "", "general", false},
+	"Chocolate Dress":                {"costume", "", "", "general", false},
+	"Chocolate Knight":               {"costume", "", "", "general", false},
+	"Cinnamon":                       {"costume", "", "", "general", false},
+	"Circus":                         {"costume", "", "", "general", false},
+	"Clannad":                        {"crossover", "", "", "general", false},
+	"Clinic":                         {"costume", "", "", "general", false},
+	"Console Casual":                 {"costume", "", "", "general", false},
+	"Cooking":                        {"costume", "", "", "general", false},
+	"Cute General":                   {"costume", "", "", "general", false},
+	"Dancer":                         {"costume", "", "", "suggestive", false},
+	"Dancer 2":                       {"costume", "", "", "suggestive", false},
+	"Dangerous Swimsuit":             {"seasonal", "summer", "", "suggestive", false},
+	"Dark Awakening (Casual)":        {"costume", "", "", "general", false},
+	"Dark Awakening (Default)":       {"costume", "", "", "general", false},
+	"Dark Awakening (Godly Descent)": {"costume", "", "", "general", false},
+	"Dark Awakening (School)":        {"costume", "", "", "general", false},
+	"Dark Hero":                      {"costume", "", "", "general", false},
+	"Dark Vampire":                   {"costume", "", "", "general", false},
+	"Dark Wings":                     {"costume", "", "", "general", false},
+	"Default":                        {"everyday", "", "", "general", true},
+	"Demon":                          {"costume", "", "", "general", false},
+	"Detective":                      {"costume", "", "", "general", false},
+	"Devil":                          {"costume", "", "", "general", false},
+	"Doctor":                         {"costume", "", "", "general", false},
+	"Dream Summer":                   {"seasonal", "summer", "", "general", false},
+	"Earth Princess":                 {"costume", "", "", "general", false},
+	"Empty Handed (Winter)":          {"seasonal", "winter", "", "general", false},
+	"Endless Christmas":              {"seasonal", "christmas", "", "general", false},
+	"Evening Star":                   {"costume", "", "", "general", false},
+	"Everyone's Idol":                {"costume", "", "", "general", false},
+	"Fairy":                          {"costume", "", "", "general", false},
+	"Feathered Queen":                {"costume", "", "", "general", false},
+	"Festival Vendor":                {"costume", "", "", "general", false},
+	"Fire Clan's Leader":             {"costume", "", "", "general", false},
+	"Frog Raincoat":                  {"costume", "", "", "general", false},
+	"Galactic Patrol":                {"costume", "", "", "general", false},
+	"Game Master":                    {"costume", "", "", "general", false},
+	"Ghost Ship":                     {"costume", "", "", "general", false},
+	"Godly Descent":                  {"costume", "", "", "general", false},
+	"Great Treasure Hunter":          {"costume", "", "", "general", false},
+	"Greatest Thief":                 {"costume", "", "", "general", false},
+	"Gungnir":                        {"crossover", "", "", "general", false},
+	"Gyaru":                          {"costume", "", "", "general", false},
+	"Gym":                            {"everyday", "", "", "general", true},
+	"Halloween":                      {"seasonal", "halloween", "", "general", false},
+	"Halloween Nurse":                {"seasonal", "halloween", "", "general", false},
+	"Happy Christmas":                {"seasonal", "christmas", "", "general", false},
+	"Hatless (Casual)":               {"everyday", "", "", "general", true},
+	"Hatless (Military)":             {"costume", "", "", "general", false},
+	"Hello Kitty":                    {"crossover", "", "", "general", false},
+	"Honored Doll":                   {"costume", "", "", "general", false},
+	"Hospital (Adult)":               {"costume", "", "adult", "general", false},
+	"Hot Spring":                     {"costume", "", "", "suggestive", false},
+	"Hot Springs":                    {"costume", "", "", "suggestive", false},
+	"Ichaival":                       {"crossover", "", "", "general", false},
+	"Ikki Tousen":                    {"crossover", "", "", "suggestive", false},
+	"Illusory Summer":                {"seasonal", "summer", "", "general", false},
+	"Inexperienced Hunter":           {"costume", "", "", "general", false},
+	"Invincible Sailor":              {"costume", "", "", "general", false},
+	"Kanon":                          {"crossover", "", "", "general", false},
+	"Knight":                         {"costume", "", "", "general", false},
+	"Lady Agent":                     {"costume", "", "", "general", false},
+	"Lily Kimono":                    {"costume", "", "", "general", false},
+	"Long Sleeve (Default)":          {"costume", "", "", "general", false},
+	"Love Chainsaw":                  {"costume", "", "", "general", false},
+	"Mad Scientist":                  {"costume", "", "", "general", false},
+	"Magical Girl":                   {"costume", "", "", "general", false},
+	"Magical Library":                {"costume", "", "", "general", false},
+	"Magical Winter":                 {"seasonal", "winter", "", "general", false},
+	"Maid":                           {"everyday", "", "", "general", true},
+	"Military":                       {"costume", "", "", "general", false},
+	"Mion Cosplay":                   {"costume", "", "", "general", false},
+	"Mion Disguise":                  {"costume", "", "", "general", false},
+	"Mion Disguise (Casual)":         {"costume", "", "", "general", false},
+	"Mion Disguise (Casual/Bloody)":  {"costume", "", "", "general", false},
+	"Mion Disguise (School)":         {"costume", "", "", "general", false},
+	"Mion Disguise (Winter)":         {"seasonal", "winter", "", "general", false},
+	"Nata (Casual)":                  {"everyday", "", "", "general", true},
+	"New Year 2022":                  {"seasonal", "new year", "", "general", false},
+	"New Year's":                     {"seasonal", "new year", "", "general", false},
+	"Night Pool":                     {"seasonal", "summer", "", "suggestive", false},
+	"Ninja":                          {"costume", "", "", "general", false},
+	"Noble Ancient Queen":            {"costume", "", "", "general", false},
+	"North High":                     {"crossover", "", "", "general", false},
+	"Nurse":                          {"everyday", "", "", "general", true},
+	"Oiran":                          {"costume", "", "", "general", false},
+	"Okinomiya Titans":               {"costume", "", "", "general", false},
+	"One for All":                    {"costume", "", "", "general", false},
+	"One-Day Bride":                  {"costume", "", "", "general", false},
+	"Oracle":                         {"costume", "", "", "general", false},
+	"Outbreak (Military)":            {"costume", "", "", "general", false},
+	"Outbreak (School)":              {"costume", "", "", "general", false},
+	"Oyashiro (School)":              {"costume", "", "", "general", false},
+	"Oyashiro (Shrine Maiden)":       {"costume", "", "", "general", false},
+	"Oyashiro (Witch)":               {"costume", "", "", "general", false},
+	"PE Teacher of Justice":          {"costume", "", "", "general", false},
+	"Pajamas":                        {"costume", "", "", "general", false},
+	"Parade":                         {"costume", "", "", "general", false},
+	"Poolside":                       {"seasonal", "summer", "", "suggestive", false},
+	"Post Officer":                   {"costume", "", "", "general", false},
+	"Pretty Santa":                   {"seasonal", "christmas", "", "general", false},
+	"Prince":                         {"costume", "", "", "general", false},
+	"Princess":                       {"costume", "", "", "general", false},
+	"Prisoner":                       {"costume", "", "", "general", false},
+	"Pumpkin Witch":                  {"seasonal", "halloween", "", "general", false},
+	"Punishment":                     {"costume", "", "", "suggestive", false},
+	"Purin":                          {"costume", "", "", "general", false},
+	"Racing":                         {"costume", "", "", "general", false},
+	"Red Devil":                      {"costume", "", "", "general", false},
+	"Rena Disguise":                  {"costume", "", "", "general", false},
+	"Robe":                           {"costume", "", "", "general", false},
+	"Ruthless Queen":                 {"costume", "", "", "general", false},
+	"Sailor":                         {"costume", "", "", "general", false},
+	"Santa":                          {"seasonal", "christmas", "", "general", false},
+	"Sanzang":                        {"costume", "", "", "general", false},
+	"School":                         {"everyday", "", "", "general", true},
+	"School Days":                    {"crossover", "", "", "general", false},
+	"Senran Kagura":                  {"crossover", "", "", "suggestive", false},
+	"Sexy Santa":                     {"seasonal", "christmas", "", "suggestive", false},
+	"Shinju-kyo":                     {"costume", "", "", "general", false},
+	"Shinto Bride":                   {"costume", "", "", "general", false},
+	"Shion Disguise (Casual)":        {"costume", "", "", "general", false},
+	"Shion Disguise (School)":        {"costume", "", "", "general", false},
+	"Shion Disguise (Winter)":        {"seasonal", "winter", "", "general", false},
+	"Shrine Maiden":                  {"everyday", "", "", "general", true},
+	"Sniper":                         {"costume", "", "", "general", false},
+	"Snow White":                     {"costume", "", "", "general", false},
+	"Spider Girl":                    {"costume", "", "", "general", false},
+	"SteinsGate Collab":              {"crossover", "", "", "general", false},
+	"Storyteller":                    {"costume", "", "", "general", false},
+	"Student Council President":      {"costume", "", "", "general", false},
+	"Summer":                         {"seasonal", "summer", "", "general", false},
+	"Summer Bride":                   {"seasonal", "summer", "", "general", false},
+	"Summer Splash":                  {"seasonal", "summer", "", "general", false},
+	"Summer Wedding":                 {"seasonal", "summer", "", "general", false},
+	"Sun Wukong":                     {"costume", "", "", "general", false},
+	"Surgeon":                        {"costume", "", "", "general", false},
+	"Survival Leader":                {"costume", "", "", "general", false},
+	"Sweet Fantasy":                  {"costume", "", "", "general", false},
+	"Swimsuit":                       {"everyday", "summer", "", "suggestive", true},
+	"Swordsman":                      {"costume", "", "", "general", false},
+	"Taisho Roman":                   {"costume", "", "", "general", false},
+	"Teacher":                        {"costume", "", "", "general", false},
+	"Teen (Blazer)":                  {"costume", "", "teen", "general", false},
+	"Teen (Casual)":                  {"costume", "", "teen", "general", false},
+	"Teen (Halloween Maid)":          {"seasonal", "halloween", "teen", "general", false},
+	"Teen (Idol)":                    {"costume", "", "teen", "general", false},
+	"Teen (Ikki Tousen)":             {"crossover", "", "teen", "suggestive", false},
+	"Teen (New Year's 2023)":         {"seasonal", "new year", "teen", "general", false},
+	"Teen (Outbreak School/Winter)":  {"seasonal", "winter", "teen", "general", false},
+	"Teen (Oyashiro)":                {"costume", "", "teen", "general", false},
+	"Teen (Pajamas)":                 {"costume", "", "teen", "general", false},
+	"Teen (Poolside)":                {"seasonal", "summer", "teen", "suggestive", false},
+	"Teen (Santa)":                   {"seasonal", "christmas", "teen", "general", false},
+	"Teen (School 2)":                {"costume", "", "teen", "general", false},
+	"Teen (School)":                  {"costume", "", "teen", "general", false},
+	"Teen (School/Winter)":           {"seasonal", "winter", "teen", "general", false},
+	"Teen (Winter)":                  {"seasonal", "winter", "teen", "general", false},
+	"Thief":                          {"costume", "", "", "general", false},
+	"Throwing With Might":            {"costume", "", "", "general", false},
+	"Tiran":                          {"costume", "", "", "general", false},
+	"Towel":                          {"costume", "", "", "suggestive", false},
+	"Tracksuit":                      {"costume", "", "", "general", false},
+	"Tsundere Scientist":             {"costume", "", "", "general", false},
+	"Tuxedo":                         {"costume", "", "", "general", false},
+	"Valentine Love":                 {"costume", "", "", "general", false},
+	"Vampire":                        {"costume", "", "", "general", false},
+	"Watanagashi Festival":           {"costume", "", "", "general", false},
+	"Water Miko Princess":            {"costume", "", "", "general", false},
+	"Wedding":                        {"costume", "", "", "general", false},
+	"White Dress":                    {"costume", "", "", "general", false},
+	"White Kimono":                   {"costume", "", "", "general", false},
+	"Wind Princess":                  {"costume", "", "", "general", false},
+	"Winter":                         {"everyday", "winter", "", "general", true},
+	"Winter Wonderland":              {"seasonal", "winter", "", "general", false},
+	"Witch (Casual)":                 {"costume", "", "", "general", false},
+	"Witch (Eua)":                    {"costume", "", "", "general", false},
+	"Witch of Despair":               {"costume", "", "", "general", false},
+	"Witch of Greed":                 {"costume", "", "", "general", false},
+	"Witch of Lust":                  {"costume", "", "", "general", false},
+	"Youkai":                         {"costume", "", "", "general", false},
+	"Yukata":                         {"seasonal", "summer", "", "general", false},
+}
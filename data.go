@@ -43,6 +43,64 @@ var FolderMap = map[string]string{
 	"tamura":    "tamurahime",
 	"une":       "une",
 }
+// Prefix → character lookup table, matching the names in spriteChoices.
+// Differs from FolderMap where a character borrows another series' Mei art.
+var CharacterMap = map[string]string{
+	"chibimion": "mion",
+	"me":        "mion",
+	"oisi":      "ooishi",
+	"re":        "rena",
+	"ri":        "rika",
+	"sa":        "satoko",
+	"ta":        "takano",
+	"tie":       "chie",
+	"tomi":      "tomitake",
+	"kasa":      "kasai",
+	"si":        "shion",
+	"iri":       "irie",
+	"aka":       "akane",
+	"kei":       "keiichi",
+	"sato":      "satoshi",
+	"tetu":      "teppei",
+	"rina":      "rina",
+	"aks":       "akasaka",
+	"ha":        "hanyuu",
+	"oko":       "oko",
+	"kameda":    "kameda",
+	"mo":        "mo",
+	"mura":      "mura",
+	"tamura":    "tamura",
+	"une":       "une",
+}
+
+// Character → Mei folder lookup table, for the names in spriteChoices
+var CharacterFolders = map[string]string{
+	"mion":     "mion",
+	"ooishi":   "ooishi",
+	"rena":     "rena",
+	"rika":     "rika",
+	"satoko":   "satoko",
+	"takano":   "takano",
+	"chie":     "chie",
+	"tomitake": "tomitake",
+	"kasai":    "kasai",
+	"shion":    "shion",
+	"irie":     "irie",
+	"akane":    "akane",
+	"keiichi":  "keiichi",
+	"satoshi":  "satoshi",
+	"teppei":   "teppei",
+	"rina":     "youhei",
+	"akasaka":  "akasaka",
+	"hanyuu":   "hanyuu",
+	"oko":      "fuko",
+	"kameda":   "haruhi",
+	"mo":       "eua",
+	"mura":     "eua",
+	"tamura":   "tamurahime",
+	"une":      "une",
+}
+
 const (
     fuan_blush_close   = "fuan_blush_close"
     fuan_blush_open    = "fuan_blush_open"
@@ -74,6 +132,60 @@ const (
     smile_open          = "smile_open"
 )
 
+// faces from ExtendedEmotions; packs without them fall back to the base seven
+const (
+    ikari_blush_close    = "ikari_blush_close"
+    ikari_blush_open     = "ikari_blush_open"
+    ikari_close          = "ikari_close"
+    ikari_open           = "ikari_open"
+    kanashii_blush_close = "kanashii_blush_close"
+    kanashii_blush_open  = "kanashii_blush_open"
+    kanashii_close       = "kanashii_close"
+    kanashii_open        = "kanashii_open"
+    komaru_blush_close   = "komaru_blush_close"
+    komaru_blush_open    = "komaru_blush_open"
+    komaru_close         = "komaru_close"
+    komaru_open          = "komaru_open"
+    naki_blush_close     = "naki_blush_close"
+    naki_blush_open      = "naki_blush_open"
+    naki_close           = "naki_close"
+    naki_open            = "naki_open"
+    tere_blush_close     = "tere_blush_close"
+    tere_blush_open      = "tere_blush_open"
+    tere_close           = "tere_close"
+    tere_open            = "tere_open"
+)
+
+// chapters a mapping first appears in, taken from the ep comments below
+const (
+    noChapter = ""
+    ep1       = "ep1"
+    ep2       = "ep2"
+    ep3       = "ep3"
+    ep4       = "ep4"
+    ep5       = "ep5"
+    ep6       = "ep6"
+    ep7       = "ep7"
+    ep8       = "ep8"
+    rei       = "rei"
+    hou       = "hou+"
+)
+
+// Chapters in release order, used to decide which sprites a game can show
+var Chapters = []string{ep1, ep2, ep3, ep4, ep5, ep6, ep7, ep8, rei, hou}
+
+// Game exe → chapter lookup table
+var GameChapters = map[string]string{
+    "HigurashiEp01.exe": ep1,
+    "HigurashiEp02.exe": ep2,
+    "HigurashiEp03.exe": ep3,
+    "HigurashiEp04.exe": ep4,
+    "HigurashiEp05.exe": ep5,
+    "HigurashiEp06.exe": ep6,
+    "HigurashiEp07.exe": ep7,
+    "HigurashiEp08.exe": ep8,
+}
+
 func generateVariants(n int) []string {
     v := make([]string, n)
     for i := 0; i < n; i++ {
@@ -82,6 +194,8 @@ func generateVariants(n int) []string {
     return v
 }
 
+// variant names the mappings below refer to; which ones are actually
+// installed is discovered from the sprite pack (see PackVariants)
 var spriteSets = generateVariants(55)
 
 var PrefixFolderMappings = map[string]string{
@@ -113,1310 +227,1310 @@ var PrefixFolderMappings = map[string]string{
 }
 
 
-// map mangagamer sprites to mei sprites
+// map mangagamer sprites to mei sprites: {expression, variant, first chapter}
 var RawGameSprites = map[string][]string{
 	// mion 1a - normal school
 	// ep1
-	"me1a_akuwarai_a1_1": {futeki_open, spriteSets[0]},
-	"me1a_akuwarai_a1_2": {futeki_open, spriteSets[0]},
-	"me1a_def_a1_0": {smile_open, spriteSets[0]},
-	"me1a_hig_maji_a1_0": {L5_open, spriteSets[0]},
-	"me1a_huteki_a1_1": {futeki_open, spriteSets[0]},
-	"me1a_huteki_a1_2": {futeki_open, spriteSets[0]},
-	"me1a_ikari_a1_1": {sinken_open, spriteSets[0]},
-	"me1a_majime_a1_0": {sinken_open, spriteSets[0]},
-	"me1a_majime_a1_1": {sinken_open, spriteSets[0]},
-	"me1a_odoroki_a1_1": {odoroki_open, spriteSets[0]},
-	"me1a_tohoho_a1_0": {normal_open, spriteSets[0]},
-	"me1a_tohoho_a1_1": {normal_open, spriteSets[0]},
-	"me1a_tokui_a1_1": {futeki_close, spriteSets[0]},
-	"me1a_tokui_a1_2": {futeki_close, spriteSets[0]},
-	"me1a_warai_a1_1": {smile_close, spriteSets[0]},
-	"me1a_warai_a1_2": {smile_close, spriteSets[0]},
-	"me1a_wink_a1_1": {smile_close, spriteSets[0]},
-	"me1a_wink_a1_2": {smile_close, spriteSets[0]},
-	"me1a_yowaki_a1_1": {fuan_open, spriteSets[0]},
-	"me1a_yowaki_a1_2": {fuan_open, spriteSets[0]},
+	"me1a_akuwarai_a1_1": {futeki_open, spriteSets[0], ep1},
+	"me1a_akuwarai_a1_2": {futeki_open, spriteSets[0], ep1},
+	"me1a_def_a1_0": {smile_open, spriteSets[0], ep1},
+	"me1a_hig_maji_a1_0": {L5_open, spriteSets[0], ep1},
+	"me1a_huteki_a1_1": {futeki_open, spriteSets[0], ep1},
+	"me1a_huteki_a1_2": {futeki_open, spriteSets[0], ep1},
+	"me1a_ikari_a1_1": {ikari_open, spriteSets[0], ep1},
+	"me1a_majime_a1_0": {sinken_open, spriteSets[0], ep1},
+	"me1a_majime_a1_1": {sinken_open, spriteSets[0], ep1},
+	"me1a_odoroki_a1_1": {odoroki_open, spriteSets[0], ep1},
+	"me1a_tohoho_a1_0": {normal_open, spriteSets[0], ep1},
+	"me1a_tohoho_a1_1": {normal_open, spriteSets[0], ep1},
+	"me1a_tokui_a1_1": {futeki_close, spriteSets[0], ep1},
+	"me1a_tokui_a1_2": {futeki_close, spriteSets[0], ep1},
+	"me1a_warai_a1_1": {smile_close, spriteSets[0], ep1},
+	"me1a_warai_a1_2": {smile_close, spriteSets[0], ep1},
+	"me1a_wink_a1_1": {smile_close, spriteSets[0], ep1},
+	"me1a_wink_a1_2": {smile_close, spriteSets[0], ep1},
+	"me1a_yowaki_a1_1": {fuan_open, spriteSets[0], ep1},
+	"me1a_yowaki_a1_2": {fuan_open, spriteSets[0], ep1},
 	// ep2
-	"me1a_hau_a1_1": {fuan_blush_open, spriteSets[0]},
-	"me1a_odoroki_a1_2": {odoroki_open, spriteSets[0]},
+	"me1a_hau_a1_1": {tere_blush_open, spriteSets[0], ep2},
+	"me1a_odoroki_a1_2": {odoroki_open, spriteSets[0], ep2},
 	// ep3
-	"me1a_ikari_a1_2": {sinken_open, spriteSets[0]},
-	"me1a_sinmyou_a1_0": {smile_blush_open, spriteSets[0]},
-	"me1a_sinmyou_a1_1": {smile_blush_open, spriteSets[0]},
+	"me1a_ikari_a1_2": {ikari_open, spriteSets[0], ep3},
+	"me1a_sinmyou_a1_0": {smile_blush_open, spriteSets[0], ep3},
+	"me1a_sinmyou_a1_1": {smile_blush_open, spriteSets[0], ep3},
 	// ep4
-	"me1a_odoroki_a1_0": {odoroki_open, spriteSets[0]},
+	"me1a_odoroki_a1_0": {odoroki_open, spriteSets[0], ep4},
 	// ep5
-	"me1a_hau_a1_0": {fuan_blush_open, spriteSets[0]},
-	"me1a_yowaki_a1_0": {fuan_open, spriteSets[0]},
+	"me1a_hau_a1_0": {tere_blush_open, spriteSets[0], ep5},
+	"me1a_yowaki_a1_0": {fuan_open, spriteSets[0], ep5},
 	// ep6
-	"me1a_akuwarai_a1_0": {futeki_open, spriteSets[0]},
-	"me1a_huteki_a1_0": {futeki_open, spriteSets[0]},
-	"me1a_tokui_a1_0": {futeki_close, spriteSets[0]},
-	"me1a_warai_a1_0": {smile_close, spriteSets[0]},
-	"me1a_wink_a1_0": {smile_close, spriteSets[0]},
+	"me1a_akuwarai_a1_0": {futeki_open, spriteSets[0], ep6},
+	"me1a_huteki_a1_0": {futeki_open, spriteSets[0], ep6},
+	"me1a_tokui_a1_0": {futeki_close, spriteSets[0], ep6},
+	"me1a_warai_a1_0": {smile_close, spriteSets[0], ep6},
+	"me1a_wink_a1_0": {smile_close, spriteSets[0], ep6},
 	// ep7
-	"me1a_def_a1_1": {smile_open, spriteSets[0]},
+	"me1a_def_a1_1": {smile_open, spriteSets[0], ep7},
 
 	// mion 1b - thumbs school
 	// ep1
-	"me1b_akuwarai_a1_1": {futeki_open, spriteSets[0]},
-	"me1b_akuwarai_a1_2": {futeki_open, spriteSets[0]},
-	"me1b_def_a1_0": {smile_open, spriteSets[0]},
-	"me1b_huteki_a1_1": {futeki_open, spriteSets[0]},
-	"me1b_huteki_a1_2": {futeki_open, spriteSets[0]},
-	"me1b_ikari_a1_1": {sinken_open, spriteSets[0]},
-	"me1b_ikari_a1_2": {sinken_open, spriteSets[0]},
-	"me1b_majime_a1_0": {sinken_open, spriteSets[0]},
-	"me1b_odoroki_a1_1": {odoroki_open, spriteSets[0]},
-	"me1b_odoroki_a1_2": {odoroki_open, spriteSets[0]},
-	"me1b_tohoho_a1_0": {normal_open, spriteSets[0]},
-	"me1b_tohoho_a1_1": {normal_open, spriteSets[0]},
-	"me1b_tokui_a1_1": {futeki_close, spriteSets[0]},
-	"me1b_tokui_a1_2": {futeki_close, spriteSets[0]},
-	"me1b_warai_a1_1": {smile_close, spriteSets[0]},
-	"me1b_wink_a1_1": {smile_close, spriteSets[0]},
-	"me1b_wink_a1_2": {smile_close, spriteSets[0]},
-	"me1b_yowaki_a1_1": {fuan_open, spriteSets[0]},
-	"me1b_yowaki_a1_2": {fuan_open, spriteSets[0]},
+	"me1b_akuwarai_a1_1": {futeki_open, spriteSets[0], ep1},
+	"me1b_akuwarai_a1_2": {futeki_open, spriteSets[0], ep1},
+	"me1b_def_a1_0": {smile_open, spriteSets[0], ep1},
+	"me1b_huteki_a1_1": {futeki_open, spriteSets[0], ep1},
+	"me1b_huteki_a1_2": {futeki_open, spriteSets[0], ep1},
+	"me1b_ikari_a1_1": {ikari_open, spriteSets[0], ep1},
+	"me1b_ikari_a1_2": {ikari_open, spriteSets[0], ep1},
+	"me1b_majime_a1_0": {sinken_open, spriteSets[0], ep1},
+	"me1b_odoroki_a1_1": {odoroki_open, spriteSets[0], ep1},
+	"me1b_odoroki_a1_2": {odoroki_open, spriteSets[0], ep1},
+	"me1b_tohoho_a1_0": {normal_open, spriteSets[0], ep1},
+	"me1b_tohoho_a1_1": {normal_open, spriteSets[0], ep1},
+	"me1b_tokui_a1_1": {futeki_close, spriteSets[0], ep1},
+	"me1b_tokui_a1_2": {futeki_close, spriteSets[0], ep1},
+	"me1b_warai_a1_1": {smile_close, spriteSets[0], ep1},
+	"me1b_wink_a1_1": {smile_close, spriteSets[0], ep1},
+	"me1b_wink_a1_2": {smile_close, spriteSets[0], ep1},
+	"me1b_yowaki_a1_1": {fuan_open, spriteSets[0], ep1},
+	"me1b_yowaki_a1_2": {fuan_open, spriteSets[0], ep1},
 	// ep2
-	"me1b_hau_a1_1": {fuan_blush_open, spriteSets[0]},
-	"me1b_majime_a1_1": {sinken_open, spriteSets[0]},
-	"me1b_warai_a1_2": {smile_close, spriteSets[0]},
+	"me1b_hau_a1_1": {tere_blush_open, spriteSets[0], ep2},
+	"me1b_majime_a1_1": {sinken_open, spriteSets[0], ep2},
+	"me1b_warai_a1_2": {smile_close, spriteSets[0], ep2},
 	// ep3
-	"me1b_sinmyou_a1_0": {smile_blush_open, spriteSets[0]},
-	"me1b_sinmyou_a1_1": {smile_blush_open, spriteSets[0]},
+	"me1b_sinmyou_a1_0": {smile_blush_open, spriteSets[0], ep3},
+	"me1b_sinmyou_a1_1": {smile_blush_open, spriteSets[0], ep3},
 	// ep6
-	"me1b_akuwarai_a1_0": {futeki_open, spriteSets[0]},
-	"me1b_hau_a1_0": {futeki_open, spriteSets[0]},
-	"me1b_odoroki_a1_0": {odoroki_open, spriteSets[0]},
-	"me1b_tokui_a1_0": {futeki_close, spriteSets[0]},
-	"me1b_warai_a1_0": {smile_close, spriteSets[0]},
-	"me1b_wink_a1_0": {smile_close, spriteSets[0]},
+	"me1b_akuwarai_a1_0": {futeki_open, spriteSets[0], ep6},
+	"me1b_hau_a1_0": {futeki_open, spriteSets[0], ep6},
+	"me1b_odoroki_a1_0": {odoroki_open, spriteSets[0], ep6},
+	"me1b_tokui_a1_0": {futeki_close, spriteSets[0], ep6},
+	"me1b_warai_a1_0": {smile_close, spriteSets[0], ep6},
+	"me1b_wink_a1_0": {smile_close, spriteSets[0], ep6},
 	// ep7
-	"me1b_def_a1_1": {smile_open, spriteSets[0]},
+	"me1b_def_a1_1": {smile_open, spriteSets[0], ep7},
 
 	// mion 2 - casual
 	// ep1
-	"me2_akuwarai_a1_1": {futeki_open, spriteSets[1]},
-	"me2_akuwarai_a1_2": {futeki_open, spriteSets[1]},
-	"me2_def_a1_0": {smile_open, spriteSets[1]},
-	"me2_hig_maji_a1_0": {L5_open, spriteSets[1]},
-	"me2_huteki_a1_1": {futeki_open, spriteSets[1]},
-	"me2_huteki_a1_2": {futeki_open, spriteSets[1]},
-	"me2_ikari_a1_1": {sinken_open, spriteSets[1]},
-	"me2_ikari_a1_2": {sinken_open, spriteSets[1]},
-	"me2_majime_a1_0": {sinken_open, spriteSets[1]},
-	"me2_odoroki_a1_1": {odoroki_open, spriteSets[1]},
-	"me2_tohoho_a1_0": {normal_open, spriteSets[1]},
-	"me2_tohoho_a1_1": {normal_open, spriteSets[1]},
-	"me2_tokui_a1_1": {futeki_close, spriteSets[1]},
-	"me2_tokui_a1_2": {futeki_close, spriteSets[1]},
-	"me2_warai_a1_1": {smile_close, spriteSets[1]},
-	"me2_warai_a1_2": {smile_close, spriteSets[1]},
-	"me2_wink_a1_1": {smile_close, spriteSets[1]},
-	"me2_wink_a1_2": {smile_close, spriteSets[1]},
+	"me2_akuwarai_a1_1": {futeki_open, spriteSets[1], ep1},
+	"me2_akuwarai_a1_2": {futeki_open, spriteSets[1], ep1},
+	"me2_def_a1_0": {smile_open, spriteSets[1], ep1},
+	"me2_hig_maji_a1_0": {L5_open, spriteSets[1], ep1},
+	"me2_huteki_a1_1": {futeki_open, spriteSets[1], ep1},
+	"me2_huteki_a1_2": {futeki_open, spriteSets[1], ep1},
+	"me2_ikari_a1_1": {ikari_open, spriteSets[1], ep1},
+	"me2_ikari_a1_2": {ikari_open, spriteSets[1], ep1},
+	"me2_majime_a1_0": {sinken_open, spriteSets[1], ep1},
+	"me2_odoroki_a1_1": {odoroki_open, spriteSets[1], ep1},
+	"me2_tohoho_a1_0": {normal_open, spriteSets[1], ep1},
+	"me2_tohoho_a1_1": {normal_open, spriteSets[1], ep1},
+	"me2_tokui_a1_1": {futeki_close, spriteSets[1], ep1},
+	"me2_tokui_a1_2": {futeki_close, spriteSets[1], ep1},
+	"me2_warai_a1_1": {smile_close, spriteSets[1], ep1},
+	"me2_warai_a1_2": {smile_close, spriteSets[1], ep1},
+	"me2_wink_a1_1": {smile_close, spriteSets[1], ep1},
+	"me2_wink_a1_2": {smile_close, spriteSets[1], ep1},
 	// ep2
-	"me2_def_a1_1": {smile_open, spriteSets[1]},
-	"me2_hau_a1_1": {fuan_blush_open, spriteSets[1]},
-	"me2_odoroki_a1_2": {odoroki_open, spriteSets[1]},
-	"me2_sinmyou_a1_0": {smile_blush_open, spriteSets[1]},
-	"me2_sinmyou_a1_1": {smile_blush_open, spriteSets[1]},
-	"me2_yowaki_a1_1": {fuan_open, spriteSets[1]},
+	"me2_def_a1_1": {smile_open, spriteSets[1], ep2},
+	"me2_hau_a1_1": {tere_blush_open, spriteSets[1], ep2},
+	"me2_odoroki_a1_2": {odoroki_open, spriteSets[1], ep2},
+	"me2_sinmyou_a1_0": {smile_blush_open, spriteSets[1], ep2},
+	"me2_sinmyou_a1_1": {smile_blush_open, spriteSets[1], ep2},
+	"me2_yowaki_a1_1": {fuan_open, spriteSets[1], ep2},
 	// ep3
-	"me2_yowaki_a1_2": {fuan_open, spriteSets[1]},
+	"me2_yowaki_a1_2": {fuan_open, spriteSets[1], ep3},
 	// ep5
-	"me2_akuwarai_a1_0": {futeki_open, spriteSets[1]},
-	"me2_def_a1_2": {smile_open, spriteSets[1]},
+	"me2_akuwarai_a1_0": {futeki_open, spriteSets[1], ep5},
+	"me2_def_a1_2": {smile_open, spriteSets[1], ep5},
 	// ep6
-	"me2_huteki_a1_0": {futeki_open, spriteSets[1]},
-	"me2_odoroki_a1_0": {odoroki_open, spriteSets[1]},
-	"me2_warai_a1_0": {smile_close, spriteSets[1]},
-	"me2_wink_a1_0": {smile_close, spriteSets[1]},
-	"me2_yowaki_a1_0": {fuan_open, spriteSets[1]},
+	"me2_huteki_a1_0": {futeki_open, spriteSets[1], ep6},
+	"me2_odoroki_a1_0": {odoroki_open, spriteSets[1], ep6},
+	"me2_warai_a1_0": {smile_close, spriteSets[1], ep6},
+	"me2_wink_a1_0": {smile_close, spriteSets[1], ep6},
+	"me2_yowaki_a1_0": {fuan_open, spriteSets[1], ep6},
 	// ep7
-	"me2_hau_a1_2": {fuan_blush_open, spriteSets[1]},
+	"me2_hau_a1_2": {tere_blush_open, spriteSets[1], ep7},
 
 	// mion 3 - gym
 	// ep1
-	"me3_akuwarai_a1_1": {futeki_open, spriteSets[2]},
-	"me3_akuwarai_a1_2": {futeki_open, spriteSets[2]},
-	"me3_def_a1_0": {smile_open, spriteSets[2]},
-	"me3_huteki_a1_2": {futeki_open, spriteSets[2]},
-	"me3_tohoho_a1_0": {normal_open, spriteSets[2]},
-	"me3_tokui_a1_1": {futeki_close, spriteSets[2]},
-	"me3_tokui_a1_2": {futeki_close, spriteSets[2]},
-	"me3_warai_a1_2": {smile_close, spriteSets[2]},
-	"me3_wink_a1_1": {smile_close, spriteSets[2]},
-	"me3_wink_a1_2": {smile_close, spriteSets[2]},
+	"me3_akuwarai_a1_1": {futeki_open, spriteSets[2], ep1},
+	"me3_akuwarai_a1_2": {futeki_open, spriteSets[2], ep1},
+	"me3_def_a1_0": {smile_open, spriteSets[2], ep1},
+	"me3_huteki_a1_2": {futeki_open, spriteSets[2], ep1},
+	"me3_tohoho_a1_0": {normal_open, spriteSets[2], ep1},
+	"me3_tokui_a1_1": {futeki_close, spriteSets[2], ep1},
+	"me3_tokui_a1_2": {futeki_close, spriteSets[2], ep1},
+	"me3_warai_a1_2": {smile_close, spriteSets[2], ep1},
+	"me3_wink_a1_1": {smile_close, spriteSets[2], ep1},
+	"me3_wink_a1_2": {smile_close, spriteSets[2], ep1},
 	// ep2
-	"me3_huteki_a1_1": {futeki_open, spriteSets[2]},
+	"me3_huteki_a1_1": {futeki_open, spriteSets[2], ep2},
 	// ep6
-	"me3_akuwarai_a1_0": {futeki_open, spriteSets[2]},
-	"me3_huteki_a1_0": {futeki_open, spriteSets[2]},
-	"me3_ikari_a1_0": {sinken_open, spriteSets[2]},
-	"me3_majime_a1_0": {sinken_open, spriteSets[2]},
-	"me3_odoroki_a1_0": {odoroki_open},
-	"me3_tokui_a1_0": {futeki_close, spriteSets[2]},
-	"me3_warai_a1_0": {smile_close, spriteSets[2]},
-	"me3_wink_a1_0": {smile_close, spriteSets[2]},
+	"me3_akuwarai_a1_0": {futeki_open, spriteSets[2], ep6},
+	"me3_huteki_a1_0": {futeki_open, spriteSets[2], ep6},
+	"me3_ikari_a1_0": {ikari_open, spriteSets[2], ep6},
+	"me3_majime_a1_0": {sinken_open, spriteSets[2], ep6},
+	"me3_odoroki_a1_0": {odoroki_open, spriteSets[2], ep6},
+	"me3_tokui_a1_0": {futeki_close, spriteSets[2], ep6},
+	"me3_warai_a1_0": {smile_close, spriteSets[2], ep6},
+	"me3_wink_a1_0": {smile_close, spriteSets[2], ep6},
 
 	// mion 4 - school swimsuit
 	// ep1
-	"me4_akuwarai_a1_1": {futeki_open, spriteSets[5]},
-	"me4_akuwarai_a1_2": {futeki_open, spriteSets[5]},
-	"me4_huteki_a1_2": {futeki_open, spriteSets[5]},
-	"me4_wink_a1_1": {smile_close, spriteSets[5]},
-	"me4_wink_a1_2": {smile_close, spriteSets[5]},
+	"me4_akuwarai_a1_1": {futeki_open, spriteSets[5], ep1},
+	"me4_akuwarai_a1_2": {futeki_open, spriteSets[5], ep1},
+	"me4_huteki_a1_2": {futeki_open, spriteSets[5], ep1},
+	"me4_wink_a1_1": {smile_close, spriteSets[5], ep1},
+	"me4_wink_a1_2": {smile_close, spriteSets[5], ep1},
 	// rei
-	"me4_tohoho_a1_0": {normal_open, spriteSets[5]},
+	"me4_tohoho_a1_0": {normal_open, spriteSets[5], rei},
 	// hou+
-	"me4_def_a1_1": {smile_open, spriteSets[5]},
-	"me4_yowaki_a1_2": {fuan_open, spriteSets[5]},
+	"me4_def_a1_1": {smile_open, spriteSets[5], hou},
+	"me4_yowaki_a1_2": {fuan_open, spriteSets[5], hou},
 
 	// mion 5 - punishment
 	// ep6
-	"me5_akuwarai_a1_0": {futeki_open, spriteSets[12]},
-	"me5_def_a1_0": {smile_open, spriteSets[12]},
-	"me5_huteki_a1_0": {futeki_open, spriteSets[12]},
-	"me5_tohoho_a1_0": {normal_open, spriteSets[12]},
-	"me5_warai_a1_0": {smile_close, spriteSets[12]},
-	"me5_wink_a1_0": {smile_close, spriteSets[12]},
+	"me5_akuwarai_a1_0": {futeki_open, spriteSets[12], ep6},
+	"me5_def_a1_0": {smile_open, spriteSets[12], ep6},
+	"me5_huteki_a1_0": {futeki_open, spriteSets[12], ep6},
+	"me5_tohoho_a1_0": {normal_open, spriteSets[12], ep6},
+	"me5_warai_a1_0": {smile_close, spriteSets[12], ep6},
+	"me5_wink_a1_0": {smile_close, spriteSets[12], ep6},
 
 	// mion 7 - casual injured
 	// hou+
-	"me7_akuwarai_a1_2": {futeki_open, spriteSets[1]},
-	"me7_def_a1_1": {smile_open, spriteSets[1]},
-	"me7_huteki_a1_1": {futeki_open, spriteSets[1]},
-	"me7_ikari_a1_2": {sinken_open, spriteSets[1]},
-	"me7_majime_a1_0": {sinken_open, spriteSets[1]},
-	"me7_odoroki_a1_2": {odoroki_open, spriteSets[1]},
-	"me7_sinmyou_a1_0": {smile_blush_open, spriteSets[1]},
-	"me7_tohoho_a1_0": {normal_open, spriteSets[1]},
-	"me7_tokui_a1_2": {futeki_close, spriteSets[1]},
-	"me7_warai_a1_2": {smile_close, spriteSets[1]},
-	"me7_wink_a1_1": {smile_close, spriteSets[1]},
-	"me7_yowaki_a1_2": {fuan_open, spriteSets[1]},
+	"me7_akuwarai_a1_2": {futeki_open, spriteSets[1], hou},
+	"me7_def_a1_1": {smile_open, spriteSets[1], hou},
+	"me7_huteki_a1_1": {futeki_open, spriteSets[1], hou},
+	"me7_ikari_a1_2": {ikari_open, spriteSets[1], hou},
+	"me7_majime_a1_0": {sinken_open, spriteSets[1], hou},
+	"me7_odoroki_a1_2": {odoroki_open, spriteSets[1], hou},
+	"me7_sinmyou_a1_0": {smile_blush_open, spriteSets[1], hou},
+	"me7_tohoho_a1_0": {normal_open, spriteSets[1], hou},
+	"me7_tokui_a1_2": {futeki_close, spriteSets[1], hou},
+	"me7_warai_a1_2": {smile_close, spriteSets[1], hou},
+	"me7_wink_a1_1": {smile_close, spriteSets[1], hou},
+	"me7_yowaki_a1_2": {fuan_open, spriteSets[1], hou},
 
 	// mion 8 - casual swimsuit
 	// hou+
-	"me8_akuwarai_a1_2": {futeki_open, spriteSets[5]},
-	"me8_def_a1_1": {smile_open, spriteSets[5]},
-	"me8_hau_a1_1": {fuan_blush_open, spriteSets[5]},
-	"me8_huteki_a1_1": {futeki_open, spriteSets[5]},
-	"me8_odoroki_a1_2": {odoroki_open, spriteSets[5]},
-	"me8_tokui_a1_2": {futeki_close, spriteSets[5]},
-	"me8_warai_a1_2": {smile_close, spriteSets[5]},
-	"me8_wink_a1_1": {smile_close, spriteSets[5]},
-	"me8_yowaki_a1_2": {smile_close, spriteSets[5]},
+	"me8_akuwarai_a1_2": {futeki_open, spriteSets[5], hou},
+	"me8_def_a1_1": {smile_open, spriteSets[5], hou},
+	"me8_hau_a1_1": {tere_blush_open, spriteSets[5], hou},
+	"me8_huteki_a1_1": {futeki_open, spriteSets[5], hou},
+	"me8_odoroki_a1_2": {odoroki_open, spriteSets[5], hou},
+	"me8_tokui_a1_2": {futeki_close, spriteSets[5], hou},
+	"me8_warai_a1_2": {smile_close, spriteSets[5], hou},
+	"me8_wink_a1_1": {smile_close, spriteSets[5], hou},
+	"me8_yowaki_a1_2": {smile_close, spriteSets[5], hou},
 
 	// ooishi 1
 	// ep1
-	"oisi1_1_0": {smile_open, spriteSets[0]},
-	"oisi1_2_0": {futeki_close, spriteSets[0]},
-	"oisi1_2_1": {futeki_close, spriteSets[0]},
-	"oisi1_3_2": {sinken_open, spriteSets[0]},
+	"oisi1_1_0": {smile_open, spriteSets[0], ep1},
+	"oisi1_2_0": {futeki_close, spriteSets[0], ep1},
+	"oisi1_2_1": {futeki_close, spriteSets[0], ep1},
+	"oisi1_3_2": {sinken_open, spriteSets[0], ep1},
 	// ep2
-	"oisi1_5_0": {futeki_open, spriteSets[0]},
-	"oisi1_5_2": {futeki_open, spriteSets[0]},
+	"oisi1_5_0": {futeki_open, spriteSets[0], ep2},
+	"oisi1_5_2": {futeki_open, spriteSets[0], ep2},
 	// ep3
-	"oisi1_4_1": {smile_open, spriteSets[0]},
-	"oisi1_5_1": {futeki_open, spriteSets[0]},
+	"oisi1_4_1": {smile_open, spriteSets[0], ep3},
+	"oisi1_5_1": {futeki_open, spriteSets[0], ep3},
 	// ep4
-	"oisi2_6_0": {smile_open, spriteSets[1]},
-	"oisi2_7_0": {futeki_close, spriteSets[1]},
-	"oisi2_8_2": {sinken_open, spriteSets[1]},
-	"oisi2_9_1": {smile_open, spriteSets[1]},
+	"oisi2_6_0": {smile_open, spriteSets[1], ep4},
+	"oisi2_7_0": {futeki_close, spriteSets[1], ep4},
+	"oisi2_8_2": {sinken_open, spriteSets[1], ep4},
+	"oisi2_9_1": {smile_open, spriteSets[1], ep4},
 	// ep5
-	"oisi1_4_2": {smile_open, spriteSets[0]},
+	"oisi1_4_2": {smile_open, spriteSets[0], ep5},
 	// ep6
-	"oisi1_3_0": {sinken_open, spriteSets[0]},
-	"oisi1_4_0": {smile_open, spriteSets[0]},
+	"oisi1_3_0": {sinken_open, spriteSets[0], ep6},
+	"oisi1_4_0": {smile_open, spriteSets[0], ep6},
 	// ep7
-	"oisi1_2_2": {futeki_close, spriteSets[0]},
+	"oisi1_2_2": {futeki_close, spriteSets[0], ep7},
 	// rei
-	"oisi2_7_2": {futeki_close, spriteSets[1]},
-	"oisi2_10_2": {futeki_open, spriteSets[1]},
+	"oisi2_7_2": {futeki_close, spriteSets[1], rei},
+	"oisi2_10_2": {futeki_open, spriteSets[1], rei},
 
 	// rena 1a - normal school
 	// ep1
-	"re1a_bikkuri_a1_2": {odoroki_blush_open, spriteSets[0]},
-	"re1a_def_a1_0": {smile_blush_open, spriteSets[0]},
-	"re1a_def_a1_2": {smile_blush_open, spriteSets[0]},
-	"re1a_hau_a1_1": {fuan_blush_open, spriteSets[0]},
-	"re1a_hig_def_a1_0": {L5_blush_open, spriteSets[0]},
-	"re1a_hig_muhyou_a1_0": {L5_blush_open, spriteSets[0]},
-	"re1a_kaii_a1_2": {smile_blush_close, spriteSets[0]},
-	"re1a_komaru_a1_0": {fuan_blush_open, spriteSets[0]},
-	"re1a_komaru_a2_0": {fuan_blush_open, spriteSets[0]},
-	"re1a_nande_a1_1": {odoroki_blush_open, spriteSets[0]},
-	"re1a_okoru_a1_0": {sinken_blush_open, spriteSets[0]},
-	"re1a_okoru_a1_2": {sinken_blush_open, spriteSets[0]},
-	"re1a_warai_a1_2": {smile_blush_close, spriteSets[0]},
+	"re1a_bikkuri_a1_2": {odoroki_blush_open, spriteSets[0], ep1},
+	"re1a_def_a1_0": {smile_blush_open, spriteSets[0], ep1},
+	"re1a_def_a1_2": {smile_blush_open, spriteSets[0], ep1},
+	"re1a_hau_a1_1": {tere_blush_open, spriteSets[0], ep1},
+	"re1a_hig_def_a1_0": {L5_blush_open, spriteSets[0], ep1},
+	"re1a_hig_muhyou_a1_0": {L5_blush_open, spriteSets[0], ep1},
+	"re1a_kaii_a1_2": {smile_blush_close, spriteSets[0], ep1},
+	"re1a_komaru_a1_0": {komaru_blush_open, spriteSets[0], ep1},
+	"re1a_komaru_a2_0": {komaru_blush_open, spriteSets[0], ep1},
+	"re1a_nande_a1_1": {odoroki_blush_open, spriteSets[0], ep1},
+	"re1a_okoru_a1_0": {ikari_blush_open, spriteSets[0], ep1},
+	"re1a_okoru_a1_2": {ikari_blush_open, spriteSets[0], ep1},
+	"re1a_warai_a1_2": {smile_blush_close, spriteSets[0], ep1},
 	// ep4
-	"re1a_nande_a1_0": {odoroki_blush_open, spriteSets[0]},
+	"re1a_nande_a1_0": {odoroki_blush_open, spriteSets[0], ep4},
 	// ep5
-	"re1a_bikkuri_a1_1": {odoroki_blush_open, spriteSets[0]},
+	"re1a_bikkuri_a1_1": {odoroki_blush_open, spriteSets[0], ep5},
 	// ep6
-	"re1a_bikkuri_a1_0": {odoroki_blush_open, spriteSets[0]},
-	"re1a_hau_a1_0": {fuan_blush_open, spriteSets[0]},
-	"re1a_warai_a1_0": {smile_blush_close, spriteSets[0]},
+	"re1a_bikkuri_a1_0": {odoroki_blush_open, spriteSets[0], ep6},
+	"re1a_hau_a1_0": {tere_blush_open, spriteSets[0], ep6},
+	"re1a_warai_a1_0": {smile_blush_close, spriteSets[0], ep6},
 	// ep7
-	"re1a_hau_a1_2": {fuan_blush_open, spriteSets[0]},
-	"re1a_hig_okoru_a1_2": {sinken_blush_open, spriteSets[0]},
-	"re1a_nande_a1_2": {odoroki_blush_open, spriteSets[0]},
+	"re1a_hau_a1_2": {tere_blush_open, spriteSets[0], ep7},
+	"re1a_hig_okoru_a1_2": {sinken_blush_open, spriteSets[0], ep7},
+	"re1a_nande_a1_2": {odoroki_blush_open, spriteSets[0], ep7},
 	
 
 	// rena 1b - hands school
 	// ep1
-	"re1b_bikkuri_b1_2": {odoroki_blush_open, spriteSets[0]},
-	"re1b_def_b1_0": {smile_blush_open, spriteSets[0]},
-	"re1b_hau_b1_1": {fuan_blush_open, spriteSets[0]},
-	"re1b_hig_def_b1_0": {L5_blush_open, spriteSets[0]},
-	"re1b_kaii_b1_2": {smile_blush_close, spriteSets[0]},
-	"re1b_komaru_b1_0": {fuan_blush_open, spriteSets[0]},
-	"re1b_komaru_b2_0": {fuan_blush_open, spriteSets[0]},
-	"re1b_okoru_b1_0": {sinken_blush_open, spriteSets[0]},
-	"re1b_warai_b1_2": {smile_blush_close, spriteSets[0]},
+	"re1b_bikkuri_b1_2": {odoroki_blush_open, spriteSets[0], ep1},
+	"re1b_def_b1_0": {smile_blush_open, spriteSets[0], ep1},
+	"re1b_hau_b1_1": {tere_blush_open, spriteSets[0], ep1},
+	"re1b_hig_def_b1_0": {L5_blush_open, spriteSets[0], ep1},
+	"re1b_kaii_b1_2": {smile_blush_close, spriteSets[0], ep1},
+	"re1b_komaru_b1_0": {komaru_blush_open, spriteSets[0], ep1},
+	"re1b_komaru_b2_0": {komaru_blush_open, spriteSets[0], ep1},
+	"re1b_okoru_b1_0": {ikari_blush_open, spriteSets[0], ep1},
+	"re1b_warai_b1_2": {smile_blush_close, spriteSets[0], ep1},
 	// ep2
-	"re1b_nande_b1_1": {odoroki_blush_open, spriteSets[0]},
+	"re1b_nande_b1_1": {odoroki_blush_open, spriteSets[0], ep2},
 	// ep3
-	"re1b_hig_okoru_b1_2": {sinken_open, spriteSets[0]},
+	"re1b_hig_okoru_b1_2": {sinken_open, spriteSets[0], ep3},
 	// ep5
-	"re1b_def_b1_2": {smile_blush_open, spriteSets[0]},
+	"re1b_def_b1_2": {smile_blush_open, spriteSets[0], ep5},
 	// ep6
-	"re1b_bikkuri_b1_0": {odoroki_blush_open, spriteSets[0]},
-	"re1b_hau_b1_0": {fuan_blush_open, spriteSets[0]},
-	"re1b_kaii_b1_0": {smile_blush_close, spriteSets[0]},
-	"re1b_warai_b1_0": {smile_blush_close, spriteSets[0]},
+	"re1b_bikkuri_b1_0": {odoroki_blush_open, spriteSets[0], ep6},
+	"re1b_hau_b1_0": {tere_blush_open, spriteSets[0], ep6},
+	"re1b_kaii_b1_0": {smile_blush_close, spriteSets[0], ep6},
+	"re1b_warai_b1_0": {smile_blush_close, spriteSets[0], ep6},
 	// ep7
-	"re1b_bikkuri_b1_1": {odoroki_blush_open, spriteSets[0]},
-	"re1b_hau_b1_2": {fuan_blush_open, spriteSets[0]},
-	"re1b_nande_b1_2": {odoroki_blush_open, spriteSets[0]},
+	"re1b_bikkuri_b1_1": {odoroki_blush_open, spriteSets[0], ep7},
+	"re1b_hau_b1_2": {tere_blush_open, spriteSets[0], ep7},
+	"re1b_nande_b1_2": {odoroki_blush_open, spriteSets[0], ep7},
 	
 
 	// rena 2a - normal casual
 	// ep1
-	"re2a_bikkuri_a1_2": {odoroki_blush_open, spriteSets[1]},
-	"re2a_def_a1_0": {smile_blush_open, spriteSets[1]},
-	"re2a_hau_a1_1": {fuan_blush_open, spriteSets[1]},
-	"re2a_hig_def_a1_0": {L5_blush_open, spriteSets[1]},
-	"re2a_hig_muhyou_a1_0": {L5_blush_open, spriteSets[1]},
-	"re2a_kaii_a1_2": {smile_blush_close, spriteSets[1]},
-	"re2a_komaru_a1_0": {fuan_blush_open, spriteSets[1]},
-	"re2a_komaru_a2_0": {fuan_blush_open, spriteSets[1]},
-	"re2a_nande_a1_1": {odoroki_blush_open, spriteSets[1]},
-	"re2a_warai_a1_2": {smile_blush_close, spriteSets[1]},
+	"re2a_bikkuri_a1_2": {odoroki_blush_open, spriteSets[1], ep1},
+	"re2a_def_a1_0": {smile_blush_open, spriteSets[1], ep1},
+	"re2a_hau_a1_1": {tere_blush_open, spriteSets[1], ep1},
+	"re2a_hig_def_a1_0": {L5_blush_open, spriteSets[1], ep1},
+	"re2a_hig_muhyou_a1_0": {L5_blush_open, spriteSets[1], ep1},
+	"re2a_kaii_a1_2": {smile_blush_close, spriteSets[1], ep1},
+	"re2a_komaru_a1_0": {komaru_blush_open, spriteSets[1], ep1},
+	"re2a_komaru_a2_0": {komaru_blush_open, spriteSets[1], ep1},
+	"re2a_nande_a1_1": {odoroki_blush_open, spriteSets[1], ep1},
+	"re2a_warai_a1_2": {smile_blush_close, spriteSets[1], ep1},
 	// ep2
-	"re2a_okoru_a1_0": {sinken_blush_open, spriteSets[1]},
+	"re2a_okoru_a1_0": {ikari_blush_open, spriteSets[1], ep2},
 	// ep5
-	"re2a_bikkuri_a1_1": {odoroki_blush_open, spriteSets[1]},
-	"re2a_def_a1_2": {smile_blush_open, spriteSets[1]},
-	"re2a_hau_a1_2": {fuan_blush_open, spriteSets[1]},
-	"re2a_nande_a1_0": {odoroki_blush_open, spriteSets[1]},
-	"re2a_warai_a1_1": {smile_blush_close, spriteSets[1]},
+	"re2a_bikkuri_a1_1": {odoroki_blush_open, spriteSets[1], ep5},
+	"re2a_def_a1_2": {smile_blush_open, spriteSets[1], ep5},
+	"re2a_hau_a1_2": {tere_blush_open, spriteSets[1], ep5},
+	"re2a_nande_a1_0": {odoroki_blush_open, spriteSets[1], ep5},
+	"re2a_warai_a1_1": {smile_blush_close, spriteSets[1], ep5},
 	// ep6
-	"re2a_bikkuri_a1_0": {odoroki_blush_open, spriteSets[1]},
-	"re2a_hau_a1_0": {fuan_blush_open, spriteSets[1]},
-	"re2a_hig_okoru_a1_0": {sinken_open, spriteSets[1]},
-	"re2a_kaii_a1_0": {smile_blush_close, spriteSets[1]},
-	"re2a_warai_a1_0": {smile_blush_close, spriteSets[1]},
+	"re2a_bikkuri_a1_0": {odoroki_blush_open, spriteSets[1], ep6},
+	"re2a_hau_a1_0": {tere_blush_open, spriteSets[1], ep6},
+	"re2a_hig_okoru_a1_0": {sinken_open, spriteSets[1], ep6},
+	"re2a_kaii_a1_0": {smile_blush_close, spriteSets[1], ep6},
+	"re2a_warai_a1_0": {smile_blush_close, spriteSets[1], ep6},
 	// ep7
-	"re2a_nande_a1_2": {odoroki_blush_open, spriteSets[1]},
+	"re2a_nande_a1_2": {odoroki_blush_open, spriteSets[1], ep7},
 	// rei
-	"re2a_hig_okoru_a1_2": {sinken_open, spriteSets[1]},
+	"re2a_hig_okoru_a1_2": {sinken_open, spriteSets[1], rei},
 
 
 	// rena 2b - hands casual
 	// ep1
-	"re2b_bikkuri_b1_2": {odoroki_blush_open, spriteSets[1]},
-	"re2b_def_b1_0": {smile_blush_open, spriteSets[1]},
-	"re2b_hau_b1_1": {fuan_blush_open, spriteSets[1]},
-	"re2b_hig_def_b1_0": {L5_blush_open, spriteSets[1]},
-	"re2b_hig_muhyou_b1_0": {L5_blush_open, spriteSets[1]},
-	"re2b_hig_okoru_b1_0": {sinken_blush_open, spriteSets[1]},
-	"re2b_kaii_b1_2": {smile_blush_close, spriteSets[1]},
-	"re2b_komaru_b1_0": {fuan_blush_open, spriteSets[1]},
-	"re2b_komaru_b2_1": {fuan_blush_open, spriteSets[1]},
-	"re2b_warai_b1_2": {smile_blush_close, spriteSets[1]},
+	"re2b_bikkuri_b1_2": {odoroki_blush_open, spriteSets[1], ep1},
+	"re2b_def_b1_0": {smile_blush_open, spriteSets[1], ep1},
+	"re2b_hau_b1_1": {tere_blush_open, spriteSets[1], ep1},
+	"re2b_hig_def_b1_0": {L5_blush_open, spriteSets[1], ep1},
+	"re2b_hig_muhyou_b1_0": {L5_blush_open, spriteSets[1], ep1},
+	"re2b_hig_okoru_b1_0": {sinken_blush_open, spriteSets[1], ep1},
+	"re2b_kaii_b1_2": {smile_blush_close, spriteSets[1], ep1},
+	"re2b_komaru_b1_0": {komaru_blush_open, spriteSets[1], ep1},
+	"re2b_komaru_b2_1": {komaru_blush_open, spriteSets[1], ep1},
+	"re2b_warai_b1_2": {smile_blush_close, spriteSets[1], ep1},
 	// ep2
-	"re2b_komaru_b2_0": {fuan_blush_open, spriteSets[1]},
-	"re2b_nande_b1_1": {odoroki_blush_open, spriteSets[1]},
-	"re2b_okoru_b1_0": {sinken_blush_open, spriteSets[1]},
+	"re2b_komaru_b2_0": {komaru_blush_open, spriteSets[1], ep2},
+	"re2b_nande_b1_1": {odoroki_blush_open, spriteSets[1], ep2},
+	"re2b_okoru_b1_0": {ikari_blush_open, spriteSets[1], ep2},
 	// ep3
-	"re2b_hig_okoru_b1_2": {sinken_open, spriteSets[1]},
+	"re2b_hig_okoru_b1_2": {sinken_open, spriteSets[1], ep3},
 	// ep5
-	"re2b_bikkuri_b1_1": {odoroki_blush_open, spriteSets[1]},
-	"re2b_def_b1_2": {smile_blush_open, spriteSets[1]},
-	"re2b_hau_b1_2": {fuan_blush_open, spriteSets[1]},
-	"re2b_kaii_b1_0": {smile_blush_close, spriteSets[1]},
-	"re2b_warai_b1_0": {smile_blush_close, spriteSets[1]},
-	"re2b_warai_b1_1": {smile_blush_close, spriteSets[1]},
+	"re2b_bikkuri_b1_1": {odoroki_blush_open, spriteSets[1], ep5},
+	"re2b_def_b1_2": {smile_blush_open, spriteSets[1], ep5},
+	"re2b_hau_b1_2": {tere_blush_open, spriteSets[1], ep5},
+	"re2b_kaii_b1_0": {smile_blush_close, spriteSets[1], ep5},
+	"re2b_warai_b1_0": {smile_blush_close, spriteSets[1], ep5},
+	"re2b_warai_b1_1": {smile_blush_close, spriteSets[1], ep5},
 	// ep6
-	"re2b_bikkuri_b1_0": {odoroki_blush_open, spriteSets[1]},
-	"re2b_hau_b1_0": {fuan_blush_open, spriteSets[1]},
-	"re2b_nande_b1_0": {odoroki_blush_open, spriteSets[1]},
+	"re2b_bikkuri_b1_0": {odoroki_blush_open, spriteSets[1], ep6},
+	"re2b_hau_b1_0": {tere_blush_open, spriteSets[1], ep6},
+	"re2b_nande_b1_0": {odoroki_blush_open, spriteSets[1], ep6},
 	// ep7
-	"re2b_nande_b1_2": {odoroki_blush_open, spriteSets[1]},
+	"re2b_nande_b1_2": {odoroki_blush_open, spriteSets[1], ep7},
 
 	// rena 3a - normal gym
 	// ep1
-	"re3a_bikkuri_a1_2": {odoroki_blush_open, spriteSets[38]},
-	"re3a_def_a1_0": {smile_blush_open, spriteSets[38]},
-	"re3a_hau_a1_1": {fuan_blush_open, spriteSets[38]},
-	"re3a_kaii_a1_2": {smile_blush_close, spriteSets[38]},
-	"re3a_komaru_a1_0": {fuan_blush_open, spriteSets[38]},
-	"re3a_komaru_a2_0": {fuan_blush_open, spriteSets[38]},
-	"re3a_nande_a1_1": {odoroki_blush_open, spriteSets[38]},
-	"re3a_warai_a1_2": {smile_blush_close, spriteSets[38]},
+	"re3a_bikkuri_a1_2": {odoroki_blush_open, spriteSets[38], ep1},
+	"re3a_def_a1_0": {smile_blush_open, spriteSets[38], ep1},
+	"re3a_hau_a1_1": {tere_blush_open, spriteSets[38], ep1},
+	"re3a_kaii_a1_2": {smile_blush_close, spriteSets[38], ep1},
+	"re3a_komaru_a1_0": {komaru_blush_open, spriteSets[38], ep1},
+	"re3a_komaru_a2_0": {komaru_blush_open, spriteSets[38], ep1},
+	"re3a_nande_a1_1": {odoroki_blush_open, spriteSets[38], ep1},
+	"re3a_warai_a1_2": {smile_blush_close, spriteSets[38], ep1},
 	// ep6
-	"re3a_hau_a1_0": {fuan_blush_open, spriteSets[38]},
-	"re3a_kaii_a1_0": {smile_blush_close, spriteSets[38]},
-	"re3a_okoru_a1_0": {sinken_blush_open, spriteSets[38]},
-	"re3a_warai_a1_0": {smile_blush_close, spriteSets[38]},
+	"re3a_hau_a1_0": {tere_blush_open, spriteSets[38], ep6},
+	"re3a_kaii_a1_0": {smile_blush_close, spriteSets[38], ep6},
+	"re3a_okoru_a1_0": {ikari_blush_open, spriteSets[38], ep6},
+	"re3a_warai_a1_0": {smile_blush_close, spriteSets[38], ep6},
 	// rei
-	"re3a_hau_a1_2": {fuan_blush_open, spriteSets[38]},
+	"re3a_hau_a1_2": {tere_blush_open, spriteSets[38], rei},
 
 	// rena 3b - hands gym
 	// ep1
-	"re3b_bikkuri_b1_2": {odoroki_blush_open, spriteSets[38]},
-	"re3b_hau_b1_1": {fuan_blush_open, spriteSets[38]},
-	"re3b_kaii_b1_2": {smile_blush_close, spriteSets[38]},
-	"re3b_warai_b1_2": {smile_blush_close, spriteSets[38]},
+	"re3b_bikkuri_b1_2": {odoroki_blush_open, spriteSets[38], ep1},
+	"re3b_hau_b1_1": {tere_blush_open, spriteSets[38], ep1},
+	"re3b_kaii_b1_2": {smile_blush_close, spriteSets[38], ep1},
+	"re3b_warai_b1_2": {smile_blush_close, spriteSets[38], ep1},
 	// ep6
-	"re3b_bikkuri_b1_0": {odoroki_blush_open, spriteSets[38]},
-	"re3b_def_b1_0": {smile_blush_open, spriteSets[38]},
-	"re3b_kaii_b1_0": {smile_blush_close, spriteSets[38]},
-	"re3b_komaru_b1_0": {fuan_blush_open, spriteSets[38]},
-	"re3b_nande_b1_0": {odoroki_blush_open, spriteSets[38]},
-	"re3b_okoru_b1_0": {sinken_blush_open, spriteSets[38]},
-	"re3b_warai_b1_0": {smile_blush_close, spriteSets[38]},
+	"re3b_bikkuri_b1_0": {odoroki_blush_open, spriteSets[38], ep6},
+	"re3b_def_b1_0": {smile_blush_open, spriteSets[38], ep6},
+	"re3b_kaii_b1_0": {smile_blush_close, spriteSets[38], ep6},
+	"re3b_komaru_b1_0": {komaru_blush_open, spriteSets[38], ep6},
+	"re3b_nande_b1_0": {odoroki_blush_open, spriteSets[38], ep6},
+	"re3b_okoru_b1_0": {ikari_blush_open, spriteSets[38], ep6},
+	"re3b_warai_b1_0": {smile_blush_close, spriteSets[38], ep6},
 
 	// rena 6 - swimsuit
 	// hou+
-	"re6_bikkuri_a1_1": {odoroki_blush_open, spriteSets[31]},
-	"re6_def_a1_2": {smile_blush_open, spriteSets[31]},
-	"re6_hau_a1_2": {fuan_blush_open, spriteSets[31]},
-	"re6_kaii_a1_2": {smile_blush_close, spriteSets[31]},
-	"re6_komaru_a1_0": {fuan_blush_open, spriteSets[31]},
-	"re6_nande_a1_2": {odoroki_blush_open, spriteSets[31]},
-	"re6_warai_a1_2": {smile_blush_close, spriteSets[31]},
+	"re6_bikkuri_a1_1": {odoroki_blush_open, spriteSets[31], hou},
+	"re6_def_a1_2": {smile_blush_open, spriteSets[31], hou},
+	"re6_hau_a1_2": {tere_blush_open, spriteSets[31], hou},
+	"re6_kaii_a1_2": {smile_blush_close, spriteSets[31], hou},
+	"re6_komaru_a1_0": {komaru_blush_open, spriteSets[31], hou},
+	"re6_nande_a1_2": {odoroki_blush_open, spriteSets[31], hou},
+	"re6_warai_a1_2": {smile_blush_close, spriteSets[31], hou},
 
 	// renasen 1 - nata attack
 	// ep6
-	"renasen1_def_0": {sinken_blush_open, spriteSets[3]},
-	"renasen1_ikakaku_0": {sinken_blush_open, spriteSets[3]},
-	"renasen1_muhyokaku_0": {L5_blush_open, spriteSets[3]},
-	"renasen1_warai_0": {smile_blush_open, spriteSets[3]},
+	"renasen1_def_0": {sinken_blush_open, spriteSets[3], ep6},
+	"renasen1_ikakaku_0": {sinken_blush_open, spriteSets[3], ep6},
+	"renasen1_muhyokaku_0": {L5_blush_open, spriteSets[3], ep6},
+	"renasen1_warai_0": {smile_blush_open, spriteSets[3], ep6},
 	// hou+
-	"renasen1_tuukaku_0": {L5_blush_open, spriteSets[3]},
+	"renasen1_tuukaku_0": {L5_blush_open, spriteSets[3], hou},
 
 
 	// renasen 2 - nata down
 	// ep6
-	"renasen2_def_0": {sinken_blush_open, spriteSets[3]},
-	"renasen2_ikakaku_0": {sinken_blush_open, spriteSets[3]},
-	"renasen2_muhyokaku_0": {L5_blush_open, spriteSets[3]},
-	"renasen2_shinken_0": {sinken_blush_open, spriteSets[3]},
-	"renasen2_tuukaku_0": {L5_blush_open, spriteSets[3]},
-	"renasen2_warai_0": {smile_blush_open, spriteSets[3]},
+	"renasen2_def_0": {sinken_blush_open, spriteSets[3], ep6},
+	"renasen2_ikakaku_0": {sinken_blush_open, spriteSets[3], ep6},
+	"renasen2_muhyokaku_0": {L5_blush_open, spriteSets[3], ep6},
+	"renasen2_shinken_0": {sinken_blush_open, spriteSets[3], ep6},
+	"renasen2_tuukaku_0": {L5_blush_open, spriteSets[3], ep6},
+	"renasen2_warai_0": {smile_blush_open, spriteSets[3], ep6},
 
 	// rika 1 - school
 	// ep1
-	"ri1_def_a1_0": {normal_blush_open, spriteSets[0]},
-	"ri1_komaru_a1_0": {fuan_blush_open, spriteSets[0]},
-	"ri1_niko_a1_0": {smile_blush_open, spriteSets[0]},
-	"ri1_warai_a1_1": {smile_blush_close, spriteSets[0]},
+	"ri1_def_a1_0": {normal_blush_open, spriteSets[0], ep1},
+	"ri1_komaru_a1_0": {komaru_blush_open, spriteSets[0], ep1},
+	"ri1_niko_a1_0": {smile_blush_open, spriteSets[0], ep1},
+	"ri1_warai_a1_1": {smile_blush_close, spriteSets[0], ep1},
 	// ep2
-	"ri1_fuman_a1_0": {sinken_blush_open, spriteSets[0]},
-	"ri1_komaru_a2_0": {fuan_blush_open, spriteSets[0]},
-	"ri1_majime_a1_1": {sinken_blush_open, spriteSets[0]},
+	"ri1_fuman_a1_0": {ikari_blush_open, spriteSets[0], ep2},
+	"ri1_komaru_a2_0": {komaru_blush_open, spriteSets[0], ep2},
+	"ri1_majime_a1_1": {sinken_blush_open, spriteSets[0], ep2},
 	// ep3
-	"ri1_majime_a1_0": {sinken_blush_open, spriteSets[0]},
+	"ri1_majime_a1_0": {sinken_blush_open, spriteSets[0], ep3},
 	// ep5
-	"ri1_warai_a1_2": {smile_blush_close, spriteSets[0]},
+	"ri1_warai_a1_2": {smile_blush_close, spriteSets[0], ep5},
 	// ep6
-	"ri1_niyari_a1_0": {futeki_blush_open, spriteSets[0]},
-	"ri1_warai_a1_0": {smile_blush_close, spriteSets[0]},
+	"ri1_niyari_a1_0": {futeki_blush_open, spriteSets[0], ep6},
+	"ri1_warai_a1_0": {smile_blush_close, spriteSets[0], ep6},
 	// ep7
-	"ri1_majime_a1_2": {sinken_blush_open, spriteSets[0]},
-	"ri1_niko_a1_2": {smile_blush_open, spriteSets[0]},
+	"ri1_majime_a1_2": {sinken_blush_open, spriteSets[0], ep7},
+	"ri1_niko_a1_2": {smile_blush_open, spriteSets[0], ep7},
 
 	// rika 2 - casual
 	// ep1
-	"ri2_def_a1_0": {normal_blush_open, spriteSets[1]},
-	"ri2_komaru_a1_0": {fuan_blush_open, spriteSets[1]},
-	"ri2_niko_a1_0": {smile_blush_open, spriteSets[1]},
+	"ri2_def_a1_0": {normal_blush_open, spriteSets[1], ep1},
+	"ri2_komaru_a1_0": {komaru_blush_open, spriteSets[1], ep1},
+	"ri2_niko_a1_0": {smile_blush_open, spriteSets[1], ep1},
 	// ep2
-	"ri2_warai_a1_1": {smile_blush_close, spriteSets[1]},
+	"ri2_warai_a1_1": {smile_blush_close, spriteSets[1], ep2},
 	// ep3
-	"ri2_komaru_a2_0": {fuan_blush_open, spriteSets[1]},
+	"ri2_komaru_a2_0": {komaru_blush_open, spriteSets[1], ep3},
 	// ep5
-	"ri2_fuman_a1_0": {sinken_blush_open, spriteSets[1]},
-	"ri2_niyari_a1_0": {futeki_blush_open, spriteSets[1]},
-	"ri2_warai_a1_2": {smile_blush_close, spriteSets[1]},
+	"ri2_fuman_a1_0": {ikari_blush_open, spriteSets[1], ep5},
+	"ri2_niyari_a1_0": {futeki_blush_open, spriteSets[1], ep5},
+	"ri2_warai_a1_2": {smile_blush_close, spriteSets[1], ep5},
 	// ep6
-	"ri2_warai_a1_0": {smile_blush_close, spriteSets[1]},
+	"ri2_warai_a1_0": {smile_blush_close, spriteSets[1], ep6},
 	// ep7
-	"ri2_majime_a1_2": {sinken_blush_open, spriteSets[1]},
-	"ri2_niko_a1_2": {smile_blush_open, spriteSets[1]},
+	"ri2_majime_a1_2": {sinken_blush_open, spriteSets[1], ep7},
+	"ri2_niko_a1_2": {smile_blush_open, spriteSets[1], ep7},
 
 	// rika 3 - gym
 	// ep1
-	"ri3_def_a1_0": {normal_blush_open, spriteSets[4]},
-	"ri3_niko_a1_0": {smile_blush_open, spriteSets[4]},
-	"ri3_warai_a1_1": {smile_blush_close, spriteSets[4]},
+	"ri3_def_a1_0": {normal_blush_open, spriteSets[4], ep1},
+	"ri3_niko_a1_0": {smile_blush_open, spriteSets[4], ep1},
+	"ri3_warai_a1_1": {smile_blush_close, spriteSets[4], ep1},
 	// ep2
-	"ri3_komaru_a1_0": {fuan_blush_open, spriteSets[4]},
+	"ri3_komaru_a1_0": {komaru_blush_open, spriteSets[4], ep2},
 	// ep6
-	"ri3_warai_a1_0": {smile_blush_close, spriteSets[4]},
+	"ri3_warai_a1_0": {smile_blush_close, spriteSets[4], ep6},
 
 	// rika 4 - cat
 	// ep1
-	"ri4_komaru_a1_0": {fuan_blush_open, spriteSets[13]},
-	"ri4_niko_a1_0": {smile_blush_open, spriteSets[13]},
+	"ri4_komaru_a1_0": {komaru_blush_open, spriteSets[13], ep1},
+	"ri4_niko_a1_0": {smile_blush_open, spriteSets[13], ep1},
 	// rei
-	"ri4_def_a1_0": {normal_blush_open, spriteSets[13]},
-	"ri4_niko_a1_2": {smile_blush_open, spriteSets[13]},
-	"ri4_warai_a1_2": {smile_blush_close, spriteSets[13]},
+	"ri4_def_a1_0": {normal_blush_open, spriteSets[13], rei},
+	"ri4_niko_a1_2": {smile_blush_open, spriteSets[13], rei},
+	"ri4_warai_a1_2": {smile_blush_close, spriteSets[13], rei},
 
 	// rika 5 - miko
 	// ep1
-	"ri5_def_a1_0": {normal_blush_open, spriteSets[10]},
-	"ri5_komaru_a1_0": {fuan_blush_open, spriteSets[10]},
-	"ri5_niko_a1_0": {smile_blush_open, spriteSets[10]},
+	"ri5_def_a1_0": {normal_blush_open, spriteSets[10], ep1},
+	"ri5_komaru_a1_0": {komaru_blush_open, spriteSets[10], ep1},
+	"ri5_niko_a1_0": {smile_blush_open, spriteSets[10], ep1},
 	// ep2
-	"ri5_warai_a1_1": {smile_blush_close, spriteSets[10]},
+	"ri5_warai_a1_1": {smile_blush_close, spriteSets[10], ep2},
 	// ep5
-	"ri5_niko_a1_2": {smile_blush_open, spriteSets[10]},
-	"ri5_warai_a1_2": {smile_blush_close, spriteSets[10]},
+	"ri5_niko_a1_2": {smile_blush_open, spriteSets[10], ep5},
+	"ri5_warai_a1_2": {smile_blush_close, spriteSets[10], ep5},
 
 	// rika 6 - angel mort
 	// ep6
-	"ri6_def_a1_0": {normal_blush_open, spriteSets[5]},
-	"ri6_komaru_a1_0": {fuan_blush_open, spriteSets[5]},
-	"ri6_niko_a1_0": {smile_blush_open, spriteSets[5]},
-	"ri6_warai_a1_0": {smile_blush_close, spriteSets[5]},
+	"ri6_def_a1_0": {normal_blush_open, spriteSets[5], ep6},
+	"ri6_komaru_a1_0": {komaru_blush_open, spriteSets[5], ep6},
+	"ri6_niko_a1_0": {smile_blush_open, spriteSets[5], ep6},
+	"ri6_warai_a1_0": {smile_blush_close, spriteSets[5], ep6},
 	// ep8
-	"ri6_warai_a1_2": {smile_blush_close, spriteSets[5]},
+	"ri6_warai_a1_2": {smile_blush_close, spriteSets[5], ep8},
 	// rei
-	"ri6_fuman_a1_0": {sinken_blush_open, spriteSets[5]},
+	"ri6_fuman_a1_0": {ikari_blush_open, spriteSets[5], rei},
 	// hou+
-	"ri6_komaru_a2_0": {fuan_blush_open, spriteSets[5]},
+	"ri6_komaru_a2_0": {komaru_blush_open, spriteSets[5], hou},
 
 	// rika 8 - swimsuit
 	// hou+
-	"ri8_def_a1_0": {normal_blush_open, spriteSets[9]},
-	"ri8_komaru_a1_0": {fuan_blush_open, spriteSets[9]},
-	"ri8_komaru_a2_0": {fuan_blush_open, spriteSets[9]},
-	"ri8_majime_a1_2": {fuan_open, spriteSets[9]},
-	"ri8_niko_a1_2": {smile_blush_open, spriteSets[9]},
-	"ri8_niyari_a1_0": {futeki_blush_open, spriteSets[9]},
-	"ri8_warai_a1_2": {smile_blush_close, spriteSets[9]},
+	"ri8_def_a1_0": {normal_blush_open, spriteSets[9], hou},
+	"ri8_komaru_a1_0": {komaru_blush_open, spriteSets[9], hou},
+	"ri8_komaru_a2_0": {komaru_blush_open, spriteSets[9], hou},
+	"ri8_majime_a1_2": {fuan_open, spriteSets[9], hou},
+	"ri8_niko_a1_2": {smile_blush_open, spriteSets[9], hou},
+	"ri8_niyari_a1_0": {futeki_blush_open, spriteSets[9], hou},
+	"ri8_warai_a1_2": {smile_blush_close, spriteSets[9], hou},
 
 	// rika minor(?)
 	// ep4
-	"rim_def_0": {normal_blush_open, spriteSets[1]},
-	"rim_komaru_0": {fuan_blush_open, spriteSets[1]},
-	"rim_majime_0": {fuan_open, spriteSets[1]},
-	"rim_niyari_0": {futeki_blush_open, spriteSets[1]},
-	"rim_warai_0": {smile_blush_close, spriteSets[1]},
-	"rim_warai_2": {smile_blush_close, spriteSets[1]},
+	"rim_def_0": {normal_blush_open, spriteSets[1], ep4},
+	"rim_komaru_0": {komaru_blush_open, spriteSets[1], ep4},
+	"rim_majime_0": {fuan_open, spriteSets[1], ep4},
+	"rim_niyari_0": {futeki_blush_open, spriteSets[1], ep4},
+	"rim_warai_0": {smile_blush_close, spriteSets[1], ep4},
+	"rim_warai_2": {smile_blush_close, spriteSets[1], ep4},
 
 	// satoko 1a - normal school
 	// ep1
-	"sa1a_akireru_a1_0": {normal_blush_open, spriteSets[0]},
-	"sa1a_akuwarai_a1_1": {futeki_blush_open, spriteSets[0]},
-	"sa1a_def_a1_1": {smile_blush_open, spriteSets[0]},
-	"sa1a_hannbeso_a1_1": {odoroki_blush_open, spriteSets[0]},
-	"sa1a_naku_a1_1": {odoroki_blush_close, spriteSets[0]},
-	"sa1a_odoroki_a1_1": {sinken_blush_open, spriteSets[0]},
-	"sa1a_warai_a1_1": {futeki_blush_close, spriteSets[0]},
+	"sa1a_akireru_a1_0": {normal_blush_open, spriteSets[0], ep1},
+	"sa1a_akuwarai_a1_1": {futeki_blush_open, spriteSets[0], ep1},
+	"sa1a_def_a1_1": {smile_blush_open, spriteSets[0], ep1},
+	"sa1a_hannbeso_a1_1": {naki_blush_open, spriteSets[0], ep1},
+	"sa1a_naku_a1_1": {naki_blush_close, spriteSets[0], ep1},
+	"sa1a_odoroki_a1_1": {sinken_blush_open, spriteSets[0], ep1},
+	"sa1a_warai_a1_1": {futeki_blush_close, spriteSets[0], ep1},
 	// ep2
-	"sa1a_yareyare_a1_1": {normal_blush_close, spriteSets[0]},
+	"sa1a_yareyare_a1_1": {normal_blush_close, spriteSets[0], ep2},
 	// ep3
-	"sa1a_hannbeso_a3_0": {sinken_blush_open, spriteSets[0]},
-	"sa1a_hau_a1_0": {smile_blush_open, spriteSets[0]},
-	"sa1a_hau_a2_1": {smile_blush_open, spriteSets[0]},
-	"sa1a_muhyou_a1_0": {L5_open, spriteSets[0]},
-	"sa1a_muhyou_a2_0": {L5_open, spriteSets[0]},
-	"sa1a_sakebu_a1_1": {odoroki_open, spriteSets[0]},
-	"sa1a_warai_a1_0": {futeki_blush_close, spriteSets[0]},
-	"sa1a_yareyare_a1_0": {normal_close, spriteSets[0]},
-	"sa1a_yareyare_a2_0": {normal_blush_close, spriteSets[0]},
+	"sa1a_hannbeso_a3_0": {sinken_blush_open, spriteSets[0], ep3},
+	"sa1a_hau_a1_0": {smile_blush_open, spriteSets[0], ep3},
+	"sa1a_hau_a2_1": {smile_blush_open, spriteSets[0], ep3},
+	"sa1a_muhyou_a1_0": {L5_open, spriteSets[0], ep3},
+	"sa1a_muhyou_a2_0": {L5_open, spriteSets[0], ep3},
+	"sa1a_sakebu_a1_1": {odoroki_open, spriteSets[0], ep3},
+	"sa1a_warai_a1_0": {futeki_blush_close, spriteSets[0], ep3},
+	"sa1a_yareyare_a1_0": {normal_close, spriteSets[0], ep3},
+	"sa1a_yareyare_a2_0": {normal_blush_close, spriteSets[0], ep3},
 	// ep5
-	"sa1a_hannbeso_a1_0": {odoroki_blush_open, spriteSets[0]},
-	"sa1a_hannbeso_a3_2": {sinken_blush_open, spriteSets[0]},
+	"sa1a_hannbeso_a1_0": {naki_blush_open, spriteSets[0], ep5},
+	"sa1a_hannbeso_a3_2": {sinken_blush_open, spriteSets[0], ep5},
 	// ep6
-	"sa1a_akuwarai_a1_0": {futeki_blush_open, spriteSets[0]},
-	"sa1a_def_a1_0": {smile_blush_open, spriteSets[0]},
-	"sa1a_odoroki_a1_0": {sinken_blush_open, spriteSets[0]},
+	"sa1a_akuwarai_a1_0": {futeki_blush_open, spriteSets[0], ep6},
+	"sa1a_def_a1_0": {smile_blush_open, spriteSets[0], ep6},
+	"sa1a_odoroki_a1_0": {sinken_blush_open, spriteSets[0], ep6},
 	// ep7
-	"sa1a_akuwarai_a1_2": {futeki_blush_open, spriteSets[0]},
-	"sa1a_def_a1_2": {smile_blush_open, spriteSets[0]},
-	"sa1a_hau_a2_2": {smile_blush_open, spriteSets[0]},
-	"sa1a_muhyou_a2_2": {L5_open, spriteSets[0]},
-	"sa1a_naku_a1_2": {odoroki_blush_close, spriteSets[0]},
-	"sa1a_odoroki_a1_2": {sinken_blush_open, spriteSets[0]},
-	"sa1a_sakebu_a1_2": {odoroki_open, spriteSets[0]},
+	"sa1a_akuwarai_a1_2": {futeki_blush_open, spriteSets[0], ep7},
+	"sa1a_def_a1_2": {smile_blush_open, spriteSets[0], ep7},
+	"sa1a_hau_a2_2": {smile_blush_open, spriteSets[0], ep7},
+	"sa1a_muhyou_a2_2": {L5_open, spriteSets[0], ep7},
+	"sa1a_naku_a1_2": {naki_blush_close, spriteSets[0], ep7},
+	"sa1a_odoroki_a1_2": {sinken_blush_open, spriteSets[0], ep7},
+	"sa1a_sakebu_a1_2": {odoroki_open, spriteSets[0], ep7},
 
 	// satoko 1b - hands school
 	// ep1
-	"sa1b_akuwarai_b1_1": {futeki_blush_open, spriteSets[0]},
-	"sa1b_def_b1_1": {smile_blush_open, spriteSets[0]},
-	"sa1b_hannbeso_b1_1": {odoroki_blush_open, spriteSets[0]},
-	"sa1b_hannbeso_b1_2": {odoroki_blush_open, spriteSets[0]},
-	"sa1b_naku_b1_1": {odoroki_blush_close, spriteSets[0]},
-	"sa1b_odoroki_b1_1": {sinken_blush_open, spriteSets[0]},
-	"sa1b_odoroki_b1_2": {sinken_blush_open, spriteSets[0]},
-	"sa1b_warai_b1_1": {futeki_blush_close, spriteSets[0]},
-	"sa1b_yareyare_b2_1": {normal_blush_close, spriteSets[0]},
+	"sa1b_akuwarai_b1_1": {futeki_blush_open, spriteSets[0], ep1},
+	"sa1b_def_b1_1": {smile_blush_open, spriteSets[0], ep1},
+	"sa1b_hannbeso_b1_1": {naki_blush_open, spriteSets[0], ep1},
+	"sa1b_hannbeso_b1_2": {naki_blush_open, spriteSets[0], ep1},
+	"sa1b_naku_b1_1": {naki_blush_close, spriteSets[0], ep1},
+	"sa1b_odoroki_b1_1": {sinken_blush_open, spriteSets[0], ep1},
+	"sa1b_odoroki_b1_2": {sinken_blush_open, spriteSets[0], ep1},
+	"sa1b_warai_b1_1": {futeki_blush_close, spriteSets[0], ep1},
+	"sa1b_yareyare_b2_1": {normal_blush_close, spriteSets[0], ep1},
 	// ep2
-	"sa1b_akireru_b1_0": {normal_blush_open, spriteSets[0]},
+	"sa1b_akireru_b1_0": {normal_blush_open, spriteSets[0], ep2},
 	// ep3
-	"sa1b_hannbeso_b3_0": {sinken_blush_open, spriteSets[0]},
-	"sa1b_hau_b1_0": {smile_blush_open, spriteSets[0]},
-	"sa1b_hau_b2_1": {smile_blush_open, spriteSets[0]},
-	"sa1b_sakebu_b1_2": {odoroki_open, spriteSets[0]},
-	"sa1b_yareyare_b1_0": {normal_close, spriteSets[0]},
-	"sa1b_yareyare_b2_0": {normal_blush_close, spriteSets[0]},
+	"sa1b_hannbeso_b3_0": {sinken_blush_open, spriteSets[0], ep3},
+	"sa1b_hau_b1_0": {smile_blush_open, spriteSets[0], ep3},
+	"sa1b_hau_b2_1": {smile_blush_open, spriteSets[0], ep3},
+	"sa1b_sakebu_b1_2": {odoroki_open, spriteSets[0], ep3},
+	"sa1b_yareyare_b1_0": {normal_close, spriteSets[0], ep3},
+	"sa1b_yareyare_b2_0": {normal_blush_close, spriteSets[0], ep3},
 	// ep5
-	"sa1b_naku_b1_2": {normal_blush_open, spriteSets[0]},
-	"sa1b_sakebu_b1_1": {odoroki_open, spriteSets[0]},
-	"sa1b_warai_b1_0": {futeki_blush_close, spriteSets[0]},
+	"sa1b_naku_b1_2": {normal_blush_open, spriteSets[0], ep5},
+	"sa1b_sakebu_b1_1": {odoroki_open, spriteSets[0], ep5},
+	"sa1b_warai_b1_0": {futeki_blush_close, spriteSets[0], ep5},
 	// ep6
-	"sa1b_akuwarai_b1_0": {futeki_blush_open, spriteSets[0]},
-	"sa1b_def_b1_0": {smile_blush_open, spriteSets[0]},
-	"sa1b_muhyou_b1_0": {smile_blush_open, spriteSets[0]},
-	"sa1b_muhyou_b2_0": {smile_blush_open, spriteSets[0]},
-	"sa1b_odoroki_b1_0": {sinken_blush_open, spriteSets[0]},
+	"sa1b_akuwarai_b1_0": {futeki_blush_open, spriteSets[0], ep6},
+	"sa1b_def_b1_0": {smile_blush_open, spriteSets[0], ep6},
+	"sa1b_muhyou_b1_0": {smile_blush_open, spriteSets[0], ep6},
+	"sa1b_muhyou_b2_0": {smile_blush_open, spriteSets[0], ep6},
+	"sa1b_odoroki_b1_0": {sinken_blush_open, spriteSets[0], ep6},
 	// ep7
-	"sa1b_akuwarai_b1_2": {futeki_blush_open, spriteSets[0]},
-	"sa1b_def_b1_2": {smile_blush_open, spriteSets[0]},
-	"sa1b_hannbeso_b1_0": {sinken_blush_open, spriteSets[0]},
-	"sa1b_muhyou_b2_2": {smile_blush_open, spriteSets[0]},
+	"sa1b_akuwarai_b1_2": {futeki_blush_open, spriteSets[0], ep7},
+	"sa1b_def_b1_2": {smile_blush_open, spriteSets[0], ep7},
+	"sa1b_hannbeso_b1_0": {sinken_blush_open, spriteSets[0], ep7},
+	"sa1b_muhyou_b2_2": {smile_blush_open, spriteSets[0], ep7},
 
 	// satoko 2a - normal casual
 	// ep1
-	"sa2a_akireru_a1_0": {normal_blush_open, spriteSets[1]},
-	"sa2a_akuwarai_a1_1": {futeki_blush_open, spriteSets[1]},
-	"sa2a_def_a1_1": {smile_blush_open, spriteSets[1]},
-	"sa2a_hannbeso_a1_1": {odoroki_blush_open, spriteSets[1]},
-	"sa2a_naku_a1_1": {odoroki_blush_close, spriteSets[1]},
-	"sa2a_odoroki_a1_1": {sinken_blush_open, spriteSets[1]},
-	"sa2a_warai_a1_1": {futeki_blush_close, spriteSets[1]},
+	"sa2a_akireru_a1_0": {normal_blush_open, spriteSets[1], ep1},
+	"sa2a_akuwarai_a1_1": {futeki_blush_open, spriteSets[1], ep1},
+	"sa2a_def_a1_1": {smile_blush_open, spriteSets[1], ep1},
+	"sa2a_hannbeso_a1_1": {naki_blush_open, spriteSets[1], ep1},
+	"sa2a_naku_a1_1": {naki_blush_close, spriteSets[1], ep1},
+	"sa2a_odoroki_a1_1": {sinken_blush_open, spriteSets[1], ep1},
+	"sa2a_warai_a1_1": {futeki_blush_close, spriteSets[1], ep1},
 	// ep2
-	"sa2a_naku_a1_2": {odoroki_blush_close, spriteSets[1]},
+	"sa2a_naku_a1_2": {naki_blush_close, spriteSets[1], ep2},
 	// ep3
-	"sa2a_hau_a1_0": {smile_blush_open, spriteSets[1]},
-	"sa2a_hau_a2_1": {smile_blush_open, spriteSets[1]},
-	"sa2a_yareyare_a1_0": {normal_close, spriteSets[1]},
-	"sa2a_yareyare_a2_0": {normal_blush_close, spriteSets[1]},
+	"sa2a_hau_a1_0": {smile_blush_open, spriteSets[1], ep3},
+	"sa2a_hau_a2_1": {smile_blush_open, spriteSets[1], ep3},
+	"sa2a_yareyare_a1_0": {normal_close, spriteSets[1], ep3},
+	"sa2a_yareyare_a2_0": {normal_blush_close, spriteSets[1], ep3},
 	// ep5
-	"sa2a_hannbeso_a1_0": {odoroki_blush_open, spriteSets[1]},
-	"sa2a_muhyou_a1_0": {smile_blush_open, spriteSets[1]},
-	"sa2a_muhyou_a2_2": {smile_blush_open, spriteSets[1]},
-	"sa2a_naku_a1_0": {odoroki_blush_close, spriteSets[1]},
-	"sa2a_warai_a1_0": {futeki_blush_close, spriteSets[1]},
-	"sa2a_def_a1_0": {smile_blush_open, spriteSets[1]},
-	"sa2a_odoroki_a1_0": {sinken_blush_open, spriteSets[1]},
+	"sa2a_hannbeso_a1_0": {naki_blush_open, spriteSets[1], ep5},
+	"sa2a_muhyou_a1_0": {smile_blush_open, spriteSets[1], ep5},
+	"sa2a_muhyou_a2_2": {smile_blush_open, spriteSets[1], ep5},
+	"sa2a_naku_a1_0": {naki_blush_close, spriteSets[1], ep5},
+	"sa2a_warai_a1_0": {futeki_blush_close, spriteSets[1], ep5},
+	"sa2a_def_a1_0": {smile_blush_open, spriteSets[1], ep5},
+	"sa2a_odoroki_a1_0": {sinken_blush_open, spriteSets[1], ep5},
 	// ep7
-	"sa2a_akuwarai_a1_2": {futeki_blush_open, spriteSets[1]},
-	"sa2a_def_a1_2": {smile_blush_open, spriteSets[1]},
-	"sa2a_hannbeso_a3_2": {odoroki_blush_open, spriteSets[1]},
-	"sa2a_hau_a1_2": {smile_blush_open, spriteSets[1]},
-	"sa2a_odoroki_a1_2": {sinken_blush_open, spriteSets[1]},
+	"sa2a_akuwarai_a1_2": {futeki_blush_open, spriteSets[1], ep7},
+	"sa2a_def_a1_2": {smile_blush_open, spriteSets[1], ep7},
+	"sa2a_hannbeso_a3_2": {naki_blush_open, spriteSets[1], ep7},
+	"sa2a_hau_a1_2": {smile_blush_open, spriteSets[1], ep7},
+	"sa2a_odoroki_a1_2": {sinken_blush_open, spriteSets[1], ep7},
 	// ep8
-	"sa2a_hau_a2_2": {smile_blush_open, spriteSets[1]},
+	"sa2a_hau_a2_2": {smile_blush_open, spriteSets[1], ep8},
 	// hou+
-	"sa2a_hannbeso_a1_2": {odoroki_blush_open, spriteSets[1]},
+	"sa2a_hannbeso_a1_2": {naki_blush_open, spriteSets[1], hou},
 
 	// satoko 2b - hands casual
 	// ep1
-	"sa2b_akireru_b1_1": {normal_blush_open, spriteSets[1]},
-	"sa2b_warai_b1_1": {futeki_blush_close, spriteSets[1]},
-	"sa2b_yareyare_b1_1": {normal_blush_close, spriteSets[1]},
+	"sa2b_akireru_b1_1": {normal_blush_open, spriteSets[1], ep1},
+	"sa2b_warai_b1_1": {futeki_blush_close, spriteSets[1], ep1},
+	"sa2b_yareyare_b1_1": {normal_blush_close, spriteSets[1], ep1},
 	// ep2
-	"sa2b_akuwarai_b1_0": {futeki_blush_open, spriteSets[1]},
-	"sa2b_def_b1_1": {smile_blush_open, spriteSets[1]},
-	"sa2b_naku_b1_1": {odoroki_blush_close, spriteSets[1]},
+	"sa2b_akuwarai_b1_0": {futeki_blush_open, spriteSets[1], ep2},
+	"sa2b_def_b1_1": {smile_blush_open, spriteSets[1], ep2},
+	"sa2b_naku_b1_1": {naki_blush_close, spriteSets[1], ep2},
 	// ep3
-	"sa2b_akireru_b1_0": {normal_blush_open, spriteSets[1]},
-	"sa2b_akuwarai_b1_1": {futeki_blush_open, spriteSets[1]},
-	"sa2b_hannbeso_b1_1": {odoroki_blush_open, spriteSets[1]},
-	"sa2b_hau_b1_0": {smile_blush_open, spriteSets[1]},
-	"sa2b_hau_b2_1": {smile_blush_open, spriteSets[1]},
-	"sa2b_odoroki_b1_1": {sinken_blush_open, spriteSets[1]},
-	"sa2b_yareyare_b1_0": {normal_close, spriteSets[1]},
-	"sa2b_yareyare_b2_0": {normal_blush_close, spriteSets[1]},
+	"sa2b_akireru_b1_0": {normal_blush_open, spriteSets[1], ep3},
+	"sa2b_akuwarai_b1_1": {futeki_blush_open, spriteSets[1], ep3},
+	"sa2b_hannbeso_b1_1": {naki_blush_open, spriteSets[1], ep3},
+	"sa2b_hau_b1_0": {smile_blush_open, spriteSets[1], ep3},
+	"sa2b_hau_b2_1": {smile_blush_open, spriteSets[1], ep3},
+	"sa2b_odoroki_b1_1": {sinken_blush_open, spriteSets[1], ep3},
+	"sa2b_yareyare_b1_0": {normal_close, spriteSets[1], ep3},
+	"sa2b_yareyare_b2_0": {normal_blush_close, spriteSets[1], ep3},
 	// ep5
-	"sa2b_muhyou_b1_0": {smile_blush_open, spriteSets[1]},
-	"sa2b_naku_b1_0": {odoroki_blush_close, spriteSets[1]},
-	"sa2b_warai_b1_0": {futeki_blush_close, spriteSets[1]},
+	"sa2b_muhyou_b1_0": {smile_blush_open, spriteSets[1], ep5},
+	"sa2b_naku_b1_0": {naki_blush_close, spriteSets[1], ep5},
+	"sa2b_warai_b1_0": {futeki_blush_close, spriteSets[1], ep5},
 	// ep6
-	"sa2b_odoroki_b1_0": {sinken_blush_open, spriteSets[1]},
+	"sa2b_odoroki_b1_0": {sinken_blush_open, spriteSets[1], ep6},
 	// ep7
-	"sa2b_akuwarai_b1_2": {futeki_blush_open, spriteSets[1]},
-	"sa2b_def_b1_2": {smile_blush_open, spriteSets[1]},
-	"sa2b_hannbeso_b1_0": {odoroki_blush_open, spriteSets[1]},
-	"sa2b_hau_b2_2": {smile_blush_open, spriteSets[1]},
-	"sa2b_odoroki_b1_2": {sinken_blush_open, spriteSets[1]},
+	"sa2b_akuwarai_b1_2": {futeki_blush_open, spriteSets[1], ep7},
+	"sa2b_def_b1_2": {smile_blush_open, spriteSets[1], ep7},
+	"sa2b_hannbeso_b1_0": {naki_blush_open, spriteSets[1], ep7},
+	"sa2b_hau_b2_2": {smile_blush_open, spriteSets[1], ep7},
+	"sa2b_odoroki_b1_2": {sinken_blush_open, spriteSets[1], ep7},
 	// hou+
-	"sa2b_sakebu_b1_2": {odoroki_open, spriteSets[1]},
+	"sa2b_sakebu_b1_2": {odoroki_open, spriteSets[1], hou},
 
 	// satoko 3 - gym
 	// ep1
-	"sa3_akireru_a1_0": {normal_blush_open, spriteSets[48]},
-	"sa3_akuwarai_a1_1": {futeki_blush_open, spriteSets[48]},
-	"sa3_def_a1_1": {smile_blush_open, spriteSets[48]},
-	"sa3_hannbeso_a1_1": {odoroki_blush_open, spriteSets[48]},
-	"sa3_odoroki_a1_1": {sinken_blush_open, spriteSets[48]},
-	"sa3_warai_a1_1": {futeki_blush_close, spriteSets[48]},
+	"sa3_akireru_a1_0": {normal_blush_open, spriteSets[48], ep1},
+	"sa3_akuwarai_a1_1": {futeki_blush_open, spriteSets[48], ep1},
+	"sa3_def_a1_1": {smile_blush_open, spriteSets[48], ep1},
+	"sa3_hannbeso_a1_1": {naki_blush_open, spriteSets[48], ep1},
+	"sa3_odoroki_a1_1": {sinken_blush_open, spriteSets[48], ep1},
+	"sa3_warai_a1_1": {futeki_blush_close, spriteSets[48], ep1},
 	// ep6
-	"sa3_akuwarai_a1_0": {futeki_blush_open, spriteSets[48]},
-	"sa3_def_a1_0": {smile_blush_open, spriteSets[48]},
-	"sa3_hannbeso_a1_0": {odoroki_blush_open, spriteSets[48]},
-	"sa3_odoroki_a1_0": {sinken_blush_open, spriteSets[48]},
-	"sa3_warai_a1_0": {futeki_blush_close, spriteSets[48]},
+	"sa3_akuwarai_a1_0": {futeki_blush_open, spriteSets[48], ep6},
+	"sa3_def_a1_0": {smile_blush_open, spriteSets[48], ep6},
+	"sa3_hannbeso_a1_0": {naki_blush_open, spriteSets[48], ep6},
+	"sa3_odoroki_a1_0": {sinken_blush_open, spriteSets[48], ep6},
+	"sa3_warai_a1_0": {futeki_blush_close, spriteSets[48], ep6},
 	
 	// satoko 4 - dog
 	// ep1
-	"sa4_akireru_a1_1": {normal_blush_open, spriteSets[0]},
-	"sa4_odoroki_a1_1": {sinken_blush_open, spriteSets[0]},
-	"sa4_warai_a1_1": {futeki_blush_close, spriteSets[0]},
+	"sa4_akireru_a1_1": {normal_blush_open, spriteSets[0], ep1},
+	"sa4_odoroki_a1_1": {sinken_blush_open, spriteSets[0], ep1},
+	"sa4_warai_a1_1": {futeki_blush_close, spriteSets[0], ep1},
 	// rei
-	"sa4_akireru_a1_0": {normal_blush_open, spriteSets[0]},
+	"sa4_akireru_a1_0": {normal_blush_open, spriteSets[0], rei},
 
 	// satoko 5 - towel
 	// ep3
-	"sa5_akireru_a1_0": {normal_open, spriteSets[7]},
-	"sa5_hannbeso_a1_1": {odoroki_open, spriteSets[7]},
-	"sa5_hannbeso_a3_1": {sinken_blush_open, spriteSets[7]},
-	"sa5_hau_a1_0": {smile_open, spriteSets[7]},
-	"sa5_odoroki_a1_1": {sinken_blush_open, spriteSets[7]},
-	"sa5_sakebu_a1_1": {odoroki_open, spriteSets[7]},
-	"sa5_warai_a1_1": {futeki_open, spriteSets[7]},
-	"sa5_yareyare_a1_0": {normal_close, spriteSets[7]},
-	"sa5_yareyare_a2_0": {normal_blush_close, spriteSets[7]},
+	"sa5_akireru_a1_0": {normal_open, spriteSets[7], ep3},
+	"sa5_hannbeso_a1_1": {naki_open, spriteSets[7], ep3},
+	"sa5_hannbeso_a3_1": {sinken_blush_open, spriteSets[7], ep3},
+	"sa5_hau_a1_0": {smile_open, spriteSets[7], ep3},
+	"sa5_odoroki_a1_1": {sinken_blush_open, spriteSets[7], ep3},
+	"sa5_sakebu_a1_1": {odoroki_open, spriteSets[7], ep3},
+	"sa5_warai_a1_1": {futeki_open, spriteSets[7], ep3},
+	"sa5_yareyare_a1_0": {normal_close, spriteSets[7], ep3},
+	"sa5_yareyare_a2_0": {normal_blush_close, spriteSets[7], ep3},
 
 	// satoko 6 - maid
 	// ep6
-	"sa6_akireru_a1_0": {normal_open, spriteSets[9]},
-	"sa6_akuwarai_a1_0": {futeki_blush_open, spriteSets[9]},
-	"sa6_hau_a1_0": {smile_open, spriteSets[9]},
-	"sa6_odoroki_a1_0": {sinken_blush_open, spriteSets[9]},
-	"sa6_warai_a1_0": {futeki_open, spriteSets[9]},
-	"sa6_yareyare_a1_0": {normal_close, spriteSets[9]},
+	"sa6_akireru_a1_0": {normal_open, spriteSets[9], ep6},
+	"sa6_akuwarai_a1_0": {futeki_blush_open, spriteSets[9], ep6},
+	"sa6_hau_a1_0": {smile_open, spriteSets[9], ep6},
+	"sa6_odoroki_a1_0": {sinken_blush_open, spriteSets[9], ep6},
+	"sa6_warai_a1_0": {futeki_open, spriteSets[9], ep6},
+	"sa6_yareyare_a1_0": {normal_close, spriteSets[9], ep6},
 	// rei
-	"sa6_yareyare_a2_0": {normal_blush_close, spriteSets[9]},
+	"sa6_yareyare_a2_0": {normal_blush_close, spriteSets[9], rei},
 
 	// satoko 8a - blue dress
 	// hou+
-	"sa8a_akuwarai_a1_2": {futeki_blush_open, spriteSets[36]},
-	"sa8a_def_a1_2": {smile_blush_open, spriteSets[36]},
-	"sa8a_warai_a1_0": {futeki_open, spriteSets[36]},
+	"sa8a_akuwarai_a1_2": {futeki_blush_open, spriteSets[36], hou},
+	"sa8a_def_a1_2": {smile_blush_open, spriteSets[36], hou},
+	"sa8a_warai_a1_0": {futeki_open, spriteSets[36], hou},
 	// satoko 9 - swimsuit
 	// hou+
-	"sa9_akireru_a1_0": {normal_open, spriteSets[2]},
-	"sa9_hannbeso_a1_2": {odoroki_open, spriteSets[2]},
-	"sa9_odoroki_a1_2": {sinken_blush_open, spriteSets[2]},
-	"sa9_warai_a1_0": {futeki_open, spriteSets[2]},
+	"sa9_akireru_a1_0": {normal_open, spriteSets[2], hou},
+	"sa9_hannbeso_a1_2": {naki_open, spriteSets[2], hou},
+	"sa9_odoroki_a1_2": {sinken_blush_open, spriteSets[2], hou},
+	"sa9_warai_a1_0": {futeki_open, spriteSets[2], hou},
 
 	// satoko 10 - swimsuit 2
 	// hou+
-	"sa10_akireru_a1_0": {normal_open, spriteSets[2]},
-	"sa10_akuwarai_a1_2": {futeki_blush_open, spriteSets[2]},
-	"sa10_def_a1_2": {smile_blush_open, spriteSets[2]},
-	"sa10_muhyou_a2_2": {smile_blush_open, spriteSets[2]},
-	"sa10_odoroki_a1_2": {sinken_blush_open, spriteSets[2]},
-	"sa10_warai_a1_0": {futeki_open, spriteSets[2]},
-	"sa10_yareyare_a1_0": {normal_close, spriteSets[2]},
-	"sa10_yareyare_a2_0": {normal_blush_close, spriteSets[2]},
+	"sa10_akireru_a1_0": {normal_open, spriteSets[2], hou},
+	"sa10_akuwarai_a1_2": {futeki_blush_open, spriteSets[2], hou},
+	"sa10_def_a1_2": {smile_blush_open, spriteSets[2], hou},
+	"sa10_muhyou_a2_2": {smile_blush_open, spriteSets[2], hou},
+	"sa10_odoroki_a1_2": {sinken_blush_open, spriteSets[2], hou},
+	"sa10_warai_a1_0": {futeki_open, spriteSets[2], hou},
+	"sa10_yareyare_a1_0": {normal_close, spriteSets[2], hou},
+	"sa10_yareyare_a2_0": {normal_blush_close, spriteSets[2], hou},
 
 	// satoko 11 - towel 2
 	// hou+
-	"sa11_akireru_a1_0": {normal_open, spriteSets[7]},
-	"sa11_odoroki_a1_2": {sinken_blush_open, spriteSets[7]},
-	"sa11_warai_a1_0": {futeki_open, spriteSets[7]},
-	"sa11_yareyare_a1_0": {normal_close, spriteSets[7]},
+	"sa11_akireru_a1_0": {normal_open, spriteSets[7], hou},
+	"sa11_odoroki_a1_2": {sinken_blush_open, spriteSets[7], hou},
+	"sa11_warai_a1_0": {futeki_open, spriteSets[7], hou},
+	"sa11_yareyare_a1_0": {normal_close, spriteSets[7], hou},
 
 	// takano 1 - casual
 	// ep1
-	"ta1_akuwarai_1": {futeki_open, spriteSets[0]},
-	"ta1_def_0": {smile_open, spriteSets[0]},
-	"ta1_def_1": {smile_open, spriteSets[0]},
-	"ta1_warai_1": {smile_close, spriteSets[0]},
+	"ta1_akuwarai_1": {futeki_open, spriteSets[0], ep1},
+	"ta1_def_0": {smile_open, spriteSets[0], ep1},
+	"ta1_def_1": {smile_open, spriteSets[0], ep1},
+	"ta1_warai_1": {smile_close, spriteSets[0], ep1},
 	// ep2
-	"ta1_hatena_0": {smile_open, spriteSets[0]},
-	"ta1_human_0": {futeki_open, spriteSets[0]},
+	"ta1_hatena_0": {smile_open, spriteSets[0], ep2},
+	"ta1_human_0": {futeki_open, spriteSets[0], ep2},
 	// ep3
-	"ta1_hatena_1": {smile_open, spriteSets[0]},
-	"ta1_human_1": {futeki_open, spriteSets[0]},
+	"ta1_hatena_1": {smile_open, spriteSets[0], ep3},
+	"ta1_human_1": {futeki_open, spriteSets[0], ep3},
 	// ep5
-	"ta1_warai_2": {smile_close, spriteSets[0]},
+	"ta1_warai_2": {smile_close, spriteSets[0], ep5},
 	// ep6
-	"ta1_akuwarai_0": {futeki_open, spriteSets[0]},
-	"ta1_warai_0": {smile_close, spriteSets[0]},
+	"ta1_akuwarai_0": {futeki_open, spriteSets[0], ep6},
+	"ta1_warai_0": {smile_close, spriteSets[0], ep6},
 	// ep7
-	"ta1_akuwarai_2": {futeki_open, spriteSets[0]},
+	"ta1_akuwarai_2": {futeki_open, spriteSets[0], ep7},
 	// ep8
-	"ta1_iradachi_0": {sinken_open, spriteSets[0]},
-	"ta1_kanashimi_0": {fuan_open, spriteSets[0]},
-	"ta1_sakebi_2": {sinken_open, spriteSets[0]},
+	"ta1_iradachi_0": {ikari_open, spriteSets[0], ep8},
+	"ta1_kanashimi_0": {kanashii_open, spriteSets[0], ep8},
+	"ta1_sakebi_2": {sinken_open, spriteSets[0], ep8},
 
 	// takano 2 - nurse
 	// ep7
-	"ta2_akuwarai_2": {futeki_open, spriteSets[1]},
-	"ta2_def_0": {smile_open, spriteSets[1]},
-	"ta2_hatena_0": {smile_open, spriteSets[1]},
-	"ta2_human_0": {futeki_open, spriteSets[1]},
-	"ta2_warai_2": {smile_close, spriteSets[1]},
+	"ta2_akuwarai_2": {futeki_open, spriteSets[1], ep7},
+	"ta2_def_0": {smile_open, spriteSets[1], ep7},
+	"ta2_hatena_0": {smile_open, spriteSets[1], ep7},
+	"ta2_human_0": {futeki_open, spriteSets[1], ep7},
+	"ta2_warai_2": {smile_close, spriteSets[1], ep7},
 	// ep8
-	"ta2_iradachi_2": {sinken_open, spriteSets[1]},
-	"ta2_kanashimi_0": {fuan_open, spriteSets[1]},
-	"ta2_sakebi_0": {sinken_open, spriteSets[1]},
-	"ta2_sakebi_2": {sinken_open, spriteSets[1]},
+	"ta2_iradachi_2": {ikari_open, spriteSets[1], ep8},
+	"ta2_kanashimi_0": {kanashii_open, spriteSets[1], ep8},
+	"ta2_sakebi_0": {sinken_open, spriteSets[1], ep8},
+	"ta2_sakebi_2": {sinken_open, spriteSets[1], ep8},
 
 	// takano 3 - army
 	// ep7
-	"ta3_akuwarai_2": {futeki_open, spriteSets[9]},
-	"ta3_def_0": {smile_open, spriteSets[9]},
+	"ta3_akuwarai_2": {futeki_open, spriteSets[9], ep7},
+	"ta3_def_0": {smile_open, spriteSets[9], ep7},
 	// ep8
-	"ta3_human_0": {futeki_open, spriteSets[9]},
-	"ta3_iradachi_0": {sinken_open, spriteSets[9]},
-	"ta3_sakebi_2": {sinken_open, spriteSets[9]},
+	"ta3_human_0": {futeki_open, spriteSets[9], ep8},
+	"ta3_iradachi_0": {ikari_open, spriteSets[9], ep8},
+	"ta3_sakebi_2": {sinken_open, spriteSets[9], ep8},
 	// hou+
-	"ta3_hatena_0": {smile_open, spriteSets[9]},
-	"ta3_warai_2": {smile_close, spriteSets[9]},
+	"ta3_hatena_0": {smile_open, spriteSets[9], hou},
+	"ta3_warai_2": {smile_close, spriteSets[9], hou},
 
 	// takano 5 - army hatless
 	// ep8
-	"ta5_akuwarai_2": {futeki_open, spriteSets[10]},
-	"ta5_human_0": {futeki_open, spriteSets[10]},
-	"ta5_iradachi_0": {sinken_open, spriteSets[10]},
-	"ta5_sakebi_2": {sinken_open, spriteSets[10]},
+	"ta5_akuwarai_2": {futeki_open, spriteSets[10], ep8},
+	"ta5_human_0": {futeki_open, spriteSets[10], ep8},
+	"ta5_iradachi_0": {ikari_open, spriteSets[10], ep8},
+	"ta5_sakebi_2": {sinken_open, spriteSets[10], ep8},
 
 	// takano 7 - army bunny
 	// ep8
-	"ta7_hatena_0": {smile_open, spriteSets[10]},
-	"ta7_sakebi_2": {sinken_open, spriteSets[10]},
+	"ta7_hatena_0": {smile_open, spriteSets[10], ep8},
+	"ta7_sakebi_2": {sinken_open, spriteSets[10], ep8},
 
 
 	// chie 1
 	// ep1
-	"tie_1_0": {smile_open, spriteSets[0]},
-	"tie_2_0": {sinken_open, spriteSets[0]},
+	"tie_1_0": {smile_open, spriteSets[0], ep1},
+	"tie_2_0": {sinken_open, spriteSets[0], ep1},
 	// ep2
-	"tie_3_1": {fuan_open, spriteSets[0]},
-	"tie_4_0": {sinken_open, spriteSets[0]},
+	"tie_3_1": {fuan_open, spriteSets[0], ep2},
+	"tie_4_0": {sinken_open, spriteSets[0], ep2},
 	// ep6
-	"tie_3_0": {fuan_open, spriteSets[0]},
+	"tie_3_0": {fuan_open, spriteSets[0], ep6},
 	// ep7
-	"tie_3_2": {fuan_open, spriteSets[0]},
+	"tie_3_2": {fuan_open, spriteSets[0], ep7},
 
 	// tomitake 1 - casual
 	// ep1
-	"tomi1_def_0": {smile_open, spriteSets[0]},
-	"tomi1_komaru_1": {fuan_open, spriteSets[0]},
-	"tomi1_warai_1": {smile_close, spriteSets[0]},
+	"tomi1_def_0": {smile_open, spriteSets[0], ep1},
+	"tomi1_komaru_1": {komaru_open, spriteSets[0], ep1},
+	"tomi1_warai_1": {smile_close, spriteSets[0], ep1},
 	// ep5
-	"tomi1_warai_2": {smile_close, spriteSets[0]},
+	"tomi1_warai_2": {smile_close, spriteSets[0], ep5},
 	// ep6
-	"tomi1_komaru_0": {fuan_open, spriteSets[0]},
-	"tomi1_warai_0": {smile_close, spriteSets[0]},
-	"tomi3_def_0": {smile_open, spriteSets[0]},
+	"tomi1_komaru_0": {komaru_open, spriteSets[0], ep6},
+	"tomi1_warai_0": {smile_close, spriteSets[0], ep6},
+	"tomi3_def_0": {smile_open, spriteSets[0], ep6},
 	// ep7
-	"tomi1_komaru_2": {fuan_open, spriteSets[0]},
+	"tomi1_komaru_2": {komaru_open, spriteSets[0], ep7},
 	// ep8
-	"tomi1_shinken_0": {sinken_open, spriteSets[0]},
-	"tomi1_shinken_2": {sinken_open, spriteSets[0]},
+	"tomi1_shinken_0": {sinken_open, spriteSets[0], ep8},
+	"tomi1_shinken_2": {sinken_open, spriteSets[0], ep8},
 	// rei
-	"tomi1_ikari_2": {sinken_open, spriteSets[0]},
+	"tomi1_ikari_2": {ikari_open, spriteSets[0], rei},
 
 	// tomitake 2 - army
 	// ep7
-	"tomi2_def_0": {smile_open, spriteSets[4]},
-	"tomi2_komaru_2": {fuan_open, spriteSets[4]},
-	"tomi2_warai_2": {smile_close, spriteSets[4]},
+	"tomi2_def_0": {smile_open, spriteSets[4], ep7},
+	"tomi2_komaru_2": {komaru_open, spriteSets[4], ep7},
+	"tomi2_warai_2": {smile_close, spriteSets[4], ep7},
 	// ep8
-	"tomi2_shinken_0": {sinken_open, spriteSets[4]},
+	"tomi2_shinken_0": {sinken_open, spriteSets[4], ep8},
 
 
 	// tomitake 3 - casual?
-	"tomi3_ikari_2": {sinken_open, spriteSets[0]},
-	"tomi3_komaru_2": {sinken_open, spriteSets[0]},
-	"tomi3_shinken_2": {sinken_open, spriteSets[0]},
-	"tomi3_warai_2": {smile_close, spriteSets[0]},
+	"tomi3_ikari_2": {ikari_open, spriteSets[0], noChapter},
+	"tomi3_komaru_2": {sinken_open, spriteSets[0], noChapter},
+	"tomi3_shinken_2": {sinken_open, spriteSets[0], noChapter},
+	"tomi3_warai_2": {smile_close, spriteSets[0], noChapter},
 	// hou+
-	"tomi3_shinken_0": {sinken_open, spriteSets[0]},
+	"tomi3_shinken_0": {sinken_open, spriteSets[0], hou},
 	
 
 	// kasai
 	// ep2
-	"kasa_1_0": {smile_open, spriteSets[0]},
-	"kasa_2_0": {odoroki_open, spriteSets[0]},
+	"kasa_1_0": {smile_open, spriteSets[0], ep2},
+	"kasa_2_0": {odoroki_open, spriteSets[0], ep2},
 	// ep5
-	"kasa_2_2": {odoroki_open, spriteSets[0]},
-	"kasa_3_0": {sinken_open, spriteSets[0]},
+	"kasa_2_2": {odoroki_open, spriteSets[0], ep5},
+	"kasa_3_0": {sinken_open, spriteSets[0], ep5},
 
 	// shion 1a - normal casual
 	// ep2
-	"si1a_akuwarai_a1_2": {futeki_blush_open, spriteSets[1]},
-	"si1a_def_a1_0": {smile_blush_open, spriteSets[1]},
-	"si1a_hau_a1_1": {fuan_blush_open, spriteSets[1]},
-	"si1a_huteki_a1_1": {futeki_blush_open, spriteSets[1]},
-	"si1a_ikari_a1_2": {sinken_blush_open, spriteSets[1]},
-	"si1a_majime_a1_0": {sinken_blush_open, spriteSets[1]},
-	"si1a_odoroki_a1_2": {odoroki_blush_open, spriteSets[1]},
-	"si1a_warai_a1_2": {smile_blush_close, spriteSets[1]},
-	"si1a_wink_a1_2": {smile_blush_close, spriteSets[1]},
-	"si1a_yowaki_a1_1": {fuan_blush_open, spriteSets[1]},
+	"si1a_akuwarai_a1_2": {futeki_blush_open, spriteSets[1], ep2},
+	"si1a_def_a1_0": {smile_blush_open, spriteSets[1], ep2},
+	"si1a_hau_a1_1": {tere_blush_open, spriteSets[1], ep2},
+	"si1a_huteki_a1_1": {futeki_blush_open, spriteSets[1], ep2},
+	"si1a_ikari_a1_2": {ikari_blush_open, spriteSets[1], ep2},
+	"si1a_majime_a1_0": {sinken_blush_open, spriteSets[1], ep2},
+	"si1a_odoroki_a1_2": {odoroki_blush_open, spriteSets[1], ep2},
+	"si1a_warai_a1_2": {smile_blush_close, spriteSets[1], ep2},
+	"si1a_wink_a1_2": {smile_blush_close, spriteSets[1], ep2},
+	"si1a_yowaki_a1_1": {fuan_blush_open, spriteSets[1], ep2},
 	// ep3
-	"si1a_tohoho_a1_0": {normal_blush_open, spriteSets[1]},
-	"si1a_tokui_a1_2": {futeki_blush_close, spriteSets[1]},
+	"si1a_tohoho_a1_0": {normal_blush_open, spriteSets[1], ep3},
+	"si1a_tokui_a1_2": {futeki_blush_close, spriteSets[1], ep3},
 	// ep6
-	"si1a_akuwarai_a1_0": {futeki_blush_open, spriteSets[1]},
-	"si1a_odoroki_a1_0": {odoroki_blush_open, spriteSets[1]},
-	"si1a_tokui_a1_0": {futeki_blush_close, spriteSets[1]},
-	"si1a_warai_a1_0": {smile_blush_close, spriteSets[1]},
-	"si1a_wink_a1_0": {smile_blush_close, spriteSets[1]},
+	"si1a_akuwarai_a1_0": {futeki_blush_open, spriteSets[1], ep6},
+	"si1a_odoroki_a1_0": {odoroki_blush_open, spriteSets[1], ep6},
+	"si1a_tokui_a1_0": {futeki_blush_close, spriteSets[1], ep6},
+	"si1a_warai_a1_0": {smile_blush_close, spriteSets[1], ep6},
+	"si1a_wink_a1_0": {smile_blush_close, spriteSets[1], ep6},
 	// ep7
-	"si1a_tokui_a1_1": {futeki_blush_close, spriteSets[1]},
-	"si1a_yowaki_a1_2": {fuan_blush_open, spriteSets[1]},
+	"si1a_tokui_a1_1": {futeki_blush_close, spriteSets[1], ep7},
+	"si1a_yowaki_a1_2": {fuan_blush_open, spriteSets[1], ep7},
 	// hou+
-	"si1a_huteki_a1_2": {futeki_blush_open, spriteSets[1]},
+	"si1a_huteki_a1_2": {futeki_blush_open, spriteSets[1], hou},
 
 	// shion 1b - hand casual
 	// ep2
-	"si1b_akuwarai_b1_2": {futeki_blush_open, spriteSets[1]},
-	"si1b_def_b1_0": {smile_blush_open, spriteSets[1]},
-	"si1b_hau_b1_1": {fuan_blush_open, spriteSets[1]},
-	"si1b_huteki_b1_1": {futeki_blush_open, spriteSets[1]},
-	"si1b_tokui_b1_2": {futeki_blush_close, spriteSets[1]},
-	"si1b_warai_b1_2": {smile_blush_close, spriteSets[1]},
-	"si1b_wink_b1_2": {smile_blush_close, spriteSets[1]},
+	"si1b_akuwarai_b1_2": {futeki_blush_open, spriteSets[1], ep2},
+	"si1b_def_b1_0": {smile_blush_open, spriteSets[1], ep2},
+	"si1b_hau_b1_1": {tere_blush_open, spriteSets[1], ep2},
+	"si1b_huteki_b1_1": {futeki_blush_open, spriteSets[1], ep2},
+	"si1b_tokui_b1_2": {futeki_blush_close, spriteSets[1], ep2},
+	"si1b_warai_b1_2": {smile_blush_close, spriteSets[1], ep2},
+	"si1b_wink_b1_2": {smile_blush_close, spriteSets[1], ep2},
 	// ep3
-	"si1b_majime_b1_0": {sinken_blush_open, spriteSets[1]},
+	"si1b_majime_b1_0": {sinken_blush_open, spriteSets[1], ep3},
 	// ep6
-	"si1b_wink_b1_0": {smile_blush_close, spriteSets[1]},
+	"si1b_wink_b1_0": {smile_blush_close, spriteSets[1], ep6},
 	// ep8
-	"si1b_odoroki_b1_2": {odoroki_blush_open, spriteSets[1]},
-	"si1b_tohoho_b1_0": {normal_blush_open, spriteSets[1]},
+	"si1b_odoroki_b1_2": {odoroki_blush_open, spriteSets[1], ep8},
+	"si1b_tohoho_b1_0": {normal_blush_open, spriteSets[1], ep8},
 	// rei
-	"si1b_yowaki_b1_2": {fuan_blush_open, spriteSets[1]},
+	"si1b_yowaki_b1_2": {fuan_blush_open, spriteSets[1], rei},
 	// hou+
-	"si1b_tokui_b1_1": {futeki_blush_close, spriteSets[1]},
+	"si1b_tokui_b1_1": {futeki_blush_close, spriteSets[1], hou},
 
 
 	// shion 2 - angel mort
 	// ep2
-	"si2_akuwarai_a1_2": {futeki_blush_open, spriteSets[3]},
-	"si2_def_a1_0": {smile_blush_open, spriteSets[3]},
-	"si2_hau_a1_1": {fuan_blush_open, spriteSets[3]},
-	"si2_huteki_a1_2": {futeki_blush_open, spriteSets[3]},
-	"si2_majime_a1_0": {sinken_blush_open, spriteSets[3]},
-	"si2_odoroki_a1_2": {odoroki_blush_open, spriteSets[3]},
-	"si2_tokui_a1_2": {futeki_blush_close, spriteSets[3]},
-	"si2_warai_a1_2": {smile_blush_close, spriteSets[3]},
-	"si2_wink_a1_2": {smile_blush_close, spriteSets[3]},
-	"si2_yowaki_a1_1": {fuan_blush_open, spriteSets[3]},
+	"si2_akuwarai_a1_2": {futeki_blush_open, spriteSets[3], ep2},
+	"si2_def_a1_0": {smile_blush_open, spriteSets[3], ep2},
+	"si2_hau_a1_1": {tere_blush_open, spriteSets[3], ep2},
+	"si2_huteki_a1_2": {futeki_blush_open, spriteSets[3], ep2},
+	"si2_majime_a1_0": {sinken_blush_open, spriteSets[3], ep2},
+	"si2_odoroki_a1_2": {odoroki_blush_open, spriteSets[3], ep2},
+	"si2_tokui_a1_2": {futeki_blush_close, spriteSets[3], ep2},
+	"si2_warai_a1_2": {smile_blush_close, spriteSets[3], ep2},
+	"si2_wink_a1_2": {smile_blush_close, spriteSets[3], ep2},
+	"si2_yowaki_a1_1": {fuan_blush_open, spriteSets[3], ep2},
 	// ep6
-	"si2_akuwarai_a1_0": {futeki_blush_open, spriteSets[3]},
-	"si2_warai_a1_0": {smile_blush_close, spriteSets[3]},
-	"si2_wink_a1_0": {smile_blush_close, spriteSets[3]},
+	"si2_akuwarai_a1_0": {futeki_blush_open, spriteSets[3], ep6},
+	"si2_warai_a1_0": {smile_blush_close, spriteSets[3], ep6},
+	"si2_wink_a1_0": {smile_blush_close, spriteSets[3], ep6},
 	// hou+
-	"si2_tohoho_a1_0": {normal_blush_open, spriteSets[3]},
-	"si2_yowaki_a1_2": {fuan_blush_open, spriteSets[3]},
+	"si2_tohoho_a1_0": {normal_blush_open, spriteSets[3], hou},
+	"si2_yowaki_a1_2": {fuan_blush_open, spriteSets[3], hou},
 
 
 	// shion 3 - school
 	// ep5
-	"si3_akuwarai_a1_1": {futeki_blush_open, spriteSets[0]},
-	"si3_def_a1_0": {smile_blush_open, spriteSets[0]},
-	"si3_tokui_a1_1": {futeki_blush_close, spriteSets[0]},
-	"si3_warai_a1_1": {smile_blush_close, spriteSets[0]},
-	"si3_wink_a1_0": {smile_blush_close, spriteSets[0]},
+	"si3_akuwarai_a1_1": {futeki_blush_open, spriteSets[0], ep5},
+	"si3_def_a1_0": {smile_blush_open, spriteSets[0], ep5},
+	"si3_tokui_a1_1": {futeki_blush_close, spriteSets[0], ep5},
+	"si3_warai_a1_1": {smile_blush_close, spriteSets[0], ep5},
+	"si3_wink_a1_0": {smile_blush_close, spriteSets[0], ep5},
 	// ep7
-	"si3_akuwarai_a1_2": {futeki_blush_open, spriteSets[0]},
-	"si3_huteki_a1_2": {futeki_blush_open, spriteSets[0]},
-	"si3_ikari_a1_2": {sinken_blush_open, spriteSets[0]},
-	"si3_majime_a1_0": {sinken_blush_open, spriteSets[0]},
-	"si3_odoroki_a1_2c": {odoroki_blush_open, spriteSets[0]},
-	"si3_tohoho_a1_0": {normal_blush_open, spriteSets[0]},
-	"si3_warai_a1_2": {smile_blush_close, spriteSets[0]},
-	"si3_yowaki_a1_2": {fuan_blush_open, spriteSets[0]},
+	"si3_akuwarai_a1_2": {futeki_blush_open, spriteSets[0], ep7},
+	"si3_huteki_a1_2": {futeki_blush_open, spriteSets[0], ep7},
+	"si3_ikari_a1_2": {ikari_blush_open, spriteSets[0], ep7},
+	"si3_majime_a1_0": {sinken_blush_open, spriteSets[0], ep7},
+	"si3_odoroki_a1_2c": {odoroki_blush_open, spriteSets[0], ep7},
+	"si3_tohoho_a1_0": {normal_blush_open, spriteSets[0], ep7},
+	"si3_warai_a1_2": {smile_blush_close, spriteSets[0], ep7},
+	"si3_yowaki_a1_2": {fuan_blush_open, spriteSets[0], ep7},
 	// rei
-	"si3_hau_a1_1": {fuan_blush_open, spriteSets[0]},
-	"si3_huteki_a1_1": {futeki_blush_open, spriteSets[0]},
-	"si3_tokui_a1_2": {futeki_blush_close, spriteSets[0]},
+	"si3_hau_a1_1": {tere_blush_open, spriteSets[0], rei},
+	"si3_huteki_a1_1": {futeki_blush_open, spriteSets[0], rei},
+	"si3_tokui_a1_2": {futeki_blush_close, spriteSets[0], rei},
 
 	// shion 5 - damaged work
 	// hou+	
-	"si5_akuwarai_a1_2": {futeki_blush_open, spriteSets[7]},
-	"si5_huteki_a1_2": {futeki_blush_open, spriteSets[7]},
-	"si5_majime_a1_0": {sinken_blush_open, spriteSets[7]},
-	"si5_odoroki_a1_2": {odoroki_blush_open, spriteSets[7]},
-	"si5_tokui_a1_1": {futeki_blush_close, spriteSets[7]},
+	"si5_akuwarai_a1_2": {futeki_blush_open, spriteSets[7], hou},
+	"si5_huteki_a1_2": {futeki_blush_open, spriteSets[7], hou},
+	"si5_majime_a1_0": {sinken_blush_open, spriteSets[7], hou},
+	"si5_odoroki_a1_2": {odoroki_blush_open, spriteSets[7], hou},
+	"si5_tokui_a1_1": {futeki_blush_close, spriteSets[7], hou},
 	// shion 6 - maid work
 	// hou+	
-	"si6_akuwarai_a1_2": {futeki_blush_open, spriteSets[2]},
-	"si6_def_a1_0": {smile_blush_open, spriteSets[2]},
-	"si6_huteki_a1_2": {futeki_blush_open, spriteSets[2]},
-	"si6_ikari_a1_2": {sinken_blush_open, spriteSets[2]},
-	"si6_majime_a1_0": {sinken_blush_open, spriteSets[2]},
-	"si6_odoroki_a1_2": {odoroki_blush_open, spriteSets[2]},
-	"si6_tohoho_a1_0": {normal_blush_open, spriteSets[2]},
-	"si6_tokui_a1_1": {futeki_blush_close, spriteSets[2]},
-	"si6_warai_a1_2": {smile_blush_close, spriteSets[2]},
-	"si6_wink_a1_0": {smile_blush_close, spriteSets[2]},
+	"si6_akuwarai_a1_2": {futeki_blush_open, spriteSets[2], hou},
+	"si6_def_a1_0": {smile_blush_open, spriteSets[2], hou},
+	"si6_huteki_a1_2": {futeki_blush_open, spriteSets[2], hou},
+	"si6_ikari_a1_2": {ikari_blush_open, spriteSets[2], hou},
+	"si6_majime_a1_0": {sinken_blush_open, spriteSets[2], hou},
+	"si6_odoroki_a1_2": {odoroki_blush_open, spriteSets[2], hou},
+	"si6_tohoho_a1_0": {normal_blush_open, spriteSets[2], hou},
+	"si6_tokui_a1_1": {futeki_blush_close, spriteSets[2], hou},
+	"si6_warai_a1_2": {smile_blush_close, spriteSets[2], hou},
+	"si6_wink_a1_0": {smile_blush_close, spriteSets[2], hou},
 
 	// irie 1 - casual
 	// ep3
-	"iri1_def1_0": {futeki_open, spriteSets[3]},
-	"iri1_def2_1": {smile_open, spriteSets[3]},
-	"iri1_majime_1": {normal_open, spriteSets[3]},
-	"iri1_majime2_1": {sinken_open, spriteSets[3]},
-	"iri1_majime3_1": {normal_open, spriteSets[3]},
-	"iri1_warai_2": {smile_open, spriteSets[3]},
+	"iri1_def1_0": {futeki_open, spriteSets[3], ep3},
+	"iri1_def2_1": {smile_open, spriteSets[3], ep3},
+	"iri1_majime_1": {normal_open, spriteSets[3], ep3},
+	"iri1_majime2_1": {sinken_open, spriteSets[3], ep3},
+	"iri1_majime3_1": {normal_open, spriteSets[3], ep3},
+	"iri1_warai_2": {smile_open, spriteSets[3], ep3},
 	// ep5
-	"iri1_def2_2": {smile_open, spriteSets[3]},
-	"iri1_majime_2": {normal_open, spriteSets[3]},
-	"iri1_majime2_0": {sinken_open, spriteSets[3]},
+	"iri1_def2_2": {smile_open, spriteSets[3], ep5},
+	"iri1_majime_2": {normal_open, spriteSets[3], ep5},
+	"iri1_majime2_0": {sinken_open, spriteSets[3], ep5},
 	// ep6
-	"iri1_majime_0": {normal_open, spriteSets[3]},
-	"iri1_warai_0": {smile_open, spriteSets[3]},
+	"iri1_majime_0": {normal_open, spriteSets[3], ep6},
+	"iri1_warai_0": {smile_open, spriteSets[3], ep6},
 
 	// irie 2 - doctor
 	// ep3
-	"iri2_def1_0": {futeki_open, spriteSets[0]},
-	"iri2_def2_1": {smile_open, spriteSets[0]},
-	"iri2_majime_1": {normal_open, spriteSets[0]},
-	"iri2_majime2_1": {sinken_open, spriteSets[0]},
-	"iri2_majime3_1": {normal_open, spriteSets[0]},
-	"iri2_warai_2": {smile_open, spriteSets[0]},
+	"iri2_def1_0": {futeki_open, spriteSets[0], ep3},
+	"iri2_def2_1": {smile_open, spriteSets[0], ep3},
+	"iri2_majime_1": {normal_open, spriteSets[0], ep3},
+	"iri2_majime2_1": {sinken_open, spriteSets[0], ep3},
+	"iri2_majime3_1": {normal_open, spriteSets[0], ep3},
+	"iri2_warai_2": {smile_open, spriteSets[0], ep3},
 	// ep5
-	"iri2_majime_2": {normal_open, spriteSets[0]},
+	"iri2_majime_2": {normal_open, spriteSets[0], ep5},
 	// ep6
-	"iri2_def2_0": {smile_open, spriteSets[0]},
-	"iri2_majime_0": {normal_open, spriteSets[0]},
-	"iri2_majime2_0": {sinken_open, spriteSets[0]},
-	"iri2_warai_0": {smile_open, spriteSets[0]},
+	"iri2_def2_0": {smile_open, spriteSets[0], ep6},
+	"iri2_majime_0": {normal_open, spriteSets[0], ep6},
+	"iri2_majime2_0": {sinken_open, spriteSets[0], ep6},
+	"iri2_warai_0": {smile_open, spriteSets[0], ep6},
 	// ep7
-	"iri2_def2_2": {smile_open, spriteSets[0]},
+	"iri2_def2_2": {smile_open, spriteSets[0], ep7},
 
 	// irie 3 - coach
 	// ep3	
-	"iri3_def1_0": {futeki_open, spriteSets[2]},
-	"iri3_def2_1": {smile_open, spriteSets[2]},
-	"iri3_warai_2": {smile_open, spriteSets[2]},
+	"iri3_def1_0": {futeki_open, spriteSets[2], ep3},
+	"iri3_def2_1": {smile_open, spriteSets[2], ep3},
+	"iri3_warai_2": {smile_open, spriteSets[2], ep3},
 	// ep5
-	"iri3_def2_2": {smile_open, spriteSets[2]},
-	"iri3_majime_2": {normal_open, spriteSets[2]},
-	"iri3_majime2_0": {sinken_open, spriteSets[2]},
+	"iri3_def2_2": {smile_open, spriteSets[2], ep5},
+	"iri3_majime_2": {normal_open, spriteSets[2], ep5},
+	"iri3_majime2_0": {sinken_open, spriteSets[2], ep5},
 	// ep6
-	"iri3_def2_0": {smile_open, spriteSets[2]},
-	"iri3_majime_0": {normal_open, spriteSets[2]},
-	"iri3_warai_0": {smile_open, spriteSets[2]},
+	"iri3_def2_0": {smile_open, spriteSets[2], ep6},
+	"iri3_majime_0": {normal_open, spriteSets[2], ep6},
+	"iri3_warai_0": {smile_open, spriteSets[2], ep6},
 
 	// chibi mion
 	// ch4
-	"chibimion_def_0": {smile_open, spriteSets[1]},
-	"chibimion_def_2": {smile_open, spriteSets[1]},
-	"chibimion_shinken_0": {normal_open, spriteSets[1]},
-	"chibimion_warai_1": {smile_close, spriteSets[1]},
-	"chibimion_warai_2": {smile_close, spriteSets[1]},
-	"chibimion_wink_0": {smile_close, spriteSets[1]},
-	"chibimion_wink_1": {smile_close, spriteSets[1]},
+	"chibimion_def_0": {smile_open, spriteSets[1], ep4},
+	"chibimion_def_2": {smile_open, spriteSets[1], ep4},
+	"chibimion_shinken_0": {normal_open, spriteSets[1], ep4},
+	"chibimion_warai_1": {smile_close, spriteSets[1], ep4},
+	"chibimion_warai_2": {smile_close, spriteSets[1], ep4},
+	"chibimion_wink_0": {smile_close, spriteSets[1], ep4},
+	"chibimion_wink_1": {smile_close, spriteSets[1], ep4},
 
 	// akane
 	// ep5
-	"aka_def_0": {normal_open, spriteSets[0]},
-	"aka_sakebi_0": {normal_open, spriteSets[0]},
-	"aka_warai_0": {normal_open, spriteSets[0]},
+	"aka_def_0": {normal_open, spriteSets[0], ep5},
+	"aka_sakebi_0": {normal_open, spriteSets[0], ep5},
+	"aka_warai_0": {normal_open, spriteSets[0], ep5},
 
 	// k1 1 - school
 	// ep5
-	"kei1_def1_0": {smile_open, spriteSets[0]},
-	"kei1_def2_0": {futeki_open, spriteSets[0]},
-	"kei1_ikari1_0": {sinken_open, spriteSets[0]},
-	"kei1_komaru_0": {fuan_open, spriteSets[0]},
-	"kei1_majime_0": {normal_open, spriteSets[0]},
-	"kei1_majime2_0": {normal_open, spriteSets[0]},
-	"kei1_nayamu_2": {sinken_close, spriteSets[0]},
-	"kei1_warai_2": {smile_close, spriteSets[0]},
+	"kei1_def1_0": {smile_open, spriteSets[0], ep5},
+	"kei1_def2_0": {futeki_open, spriteSets[0], ep5},
+	"kei1_ikari1_0": {ikari_open, spriteSets[0], ep5},
+	"kei1_komaru_0": {komaru_open, spriteSets[0], ep5},
+	"kei1_majime_0": {normal_open, spriteSets[0], ep5},
+	"kei1_majime2_0": {normal_open, spriteSets[0], ep5},
+	"kei1_nayamu_2": {sinken_close, spriteSets[0], ep5},
+	"kei1_warai_2": {smile_close, spriteSets[0], ep5},
 	// ep6
-	"kei1_warai_0": {smile_close, spriteSets[0]},
+	"kei1_warai_0": {smile_close, spriteSets[0], ep6},
 	// ep7
-	"kei1_ikari2_2": {sinken_blush_open, spriteSets[0]},
+	"kei1_ikari2_2": {ikari_blush_open, spriteSets[0], ep7},
 
 	// k1 2 - casual
 	// ep5
-	"kei2_def1_0": {smile_open, spriteSets[1]},
-	"kei2_def2_0": {futeki_open, spriteSets[1]},
-	"kei2_ikari1_0": {sinken_open, spriteSets[1]},
-	"kei2_ikari2_1": {sinken_blush_open, spriteSets[1]},
-	"kei2_komaru_0": {fuan_open, spriteSets[1]},
-	"kei2_majime_0": {normal_open, spriteSets[1]},
-	"kei2_majime2_0": {normal_open, spriteSets[1]},
-	"kei2_warai_2": {smile_close, spriteSets[1]},
+	"kei2_def1_0": {smile_open, spriteSets[1], ep5},
+	"kei2_def2_0": {futeki_open, spriteSets[1], ep5},
+	"kei2_ikari1_0": {ikari_open, spriteSets[1], ep5},
+	"kei2_ikari2_1": {ikari_blush_open, spriteSets[1], ep5},
+	"kei2_komaru_0": {komaru_open, spriteSets[1], ep5},
+	"kei2_majime_0": {normal_open, spriteSets[1], ep5},
+	"kei2_majime2_0": {normal_open, spriteSets[1], ep5},
+	"kei2_warai_2": {smile_close, spriteSets[1], ep5},
 	// ep6
-	"kei2_nayamu_0": {sinken_close, spriteSets[1]},
-	"kei2_warai_0": {smile_close, spriteSets[1]},
+	"kei2_nayamu_0": {sinken_close, spriteSets[1], ep6},
+	"kei2_warai_0": {smile_close, spriteSets[1], ep6},
 	// ep7
-	"kei2_ikari2_2": {sinken_open, spriteSets[1]},
-	"kei2_nayamu_2": {sinken_close, spriteSets[1]},
+	"kei2_ikari2_2": {ikari_open, spriteSets[1], ep7},
+	"kei2_nayamu_2": {sinken_close, spriteSets[1], ep7},
 	// hou+
-	"kei2_hig_0": {L5_open, spriteSets[1]},
-	"kei2_hig2_2": {L5_open, spriteSets[1]},
+	"kei2_hig_0": {L5_open, spriteSets[1], hou},
+	"kei2_hig2_2": {L5_open, spriteSets[1], hou},
 
 	// k1 5 - casual bat
 	// hou+
-	"kei5_def1_0": {smile_open, spriteSets[1]},
-	"kei5_def2_0": {futeki_open, spriteSets[1]},
-	"kei5_hig_0": {L5_open, spriteSets[1]},
-	"kei5_ikari1_0": {sinken_open, spriteSets[1]},
-	"kei5_ikari2_2": {sinken_blush_open, spriteSets[1]},
-	"kei5_komaru_0": {fuan_open, spriteSets[1]},
-	"kei5_nayamu_2": {sinken_close, spriteSets[1]},
-	"kei5_warai_2": {smile_close, spriteSets[1]},
+	"kei5_def1_0": {smile_open, spriteSets[1], hou},
+	"kei5_def2_0": {futeki_open, spriteSets[1], hou},
+	"kei5_hig_0": {L5_open, spriteSets[1], hou},
+	"kei5_ikari1_0": {ikari_open, spriteSets[1], hou},
+	"kei5_ikari2_2": {ikari_blush_open, spriteSets[1], hou},
+	"kei5_komaru_0": {komaru_open, spriteSets[1], hou},
+	"kei5_nayamu_2": {sinken_close, spriteSets[1], hou},
+	"kei5_warai_2": {smile_close, spriteSets[1], hou},
 
 
 	// k1 6 - maid
 	// rei
-	"kei6_komaru_0": {fuan_open, spriteSets[27]},
-	"kei6_nayamu_2": {sinken_close, spriteSets[27]},
+	"kei6_komaru_0": {komaru_open, spriteSets[27], rei},
+	"kei6_nayamu_2": {sinken_close, spriteSets[27], rei},
 
 	// k1 7 - swimsuit
 	// hou+
-	"kei7_def1_0": {smile_open, spriteSets[18]},
-	"kei7_def2_0": {futeki_open, spriteSets[18]},
-	"kei7_ikari1_0": {sinken_open, spriteSets[18]},
-	"kei7_ikari2_2": {sinken_blush_open, spriteSets[18]},
-	"kei7_komaru_0": {fuan_open, spriteSets[18]},
-	"kei7_majime_0": {normal_open, spriteSets[18]},
-	"kei7_majime2_0": {normal_open, spriteSets[18]},
-	"kei7_nayamu_2": {sinken_close, spriteSets[18]},
-	"kei7_warai_2": {smile_close, spriteSets[18]},
+	"kei7_def1_0": {smile_open, spriteSets[18], hou},
+	"kei7_def2_0": {futeki_open, spriteSets[18], hou},
+	"kei7_ikari1_0": {ikari_open, spriteSets[18], hou},
+	"kei7_ikari2_2": {ikari_blush_open, spriteSets[18], hou},
+	"kei7_komaru_0": {komaru_open, spriteSets[18], hou},
+	"kei7_majime_0": {normal_open, spriteSets[18], hou},
+	"kei7_majime2_0": {normal_open, spriteSets[18], hou},
+	"kei7_nayamu_2": {sinken_close, spriteSets[18], hou},
+	"kei7_warai_2": {smile_close, spriteSets[18], hou},
 
 	// k1 8 - girl
 	// hou+
-	"kei8_ikari1_0": {sinken_open, spriteSets[13]},
-	"kei8_ikari2_2": {sinken_blush_open, spriteSets[13]},
-	"kei8_komaru_0": {fuan_open, spriteSets[13]},
-	"kei8_majime_0": {normal_open, spriteSets[13]},
-	"kei8_nayamu_2": {sinken_close, spriteSets[13]},
-	"kei8_warai_2": {smile_close, spriteSets[13]},
+	"kei8_ikari1_0": {ikari_open, spriteSets[13], hou},
+	"kei8_ikari2_2": {ikari_blush_open, spriteSets[13], hou},
+	"kei8_komaru_0": {komaru_open, spriteSets[13], hou},
+	"kei8_majime_0": {normal_open, spriteSets[13], hou},
+	"kei8_nayamu_2": {sinken_close, spriteSets[13], hou},
+	"kei8_warai_2": {smile_close, spriteSets[13], hou},
 
 	// keisen - casual bat?
 	// hou+
-	"keisen_niramu_0": {sinken_blush_open, spriteSets[1]},
-	"keisen_shinken_0": {normal_open, spriteSets[1]},
+	"keisen_niramu_0": {sinken_blush_open, spriteSets[1], hou},
+	"keisen_shinken_0": {normal_open, spriteSets[1], hou},
 
 	// satoshi 1 - casual
 	// ep5
-	"sato1_def1_0": {smile_open, spriteSets[0]},
-	"sato1_def2_0": {smile_close, spriteSets[0]},
-	"sato1_ikari_1": {sinken_open, spriteSets[0]},
-	"sato1_komaru_0": {fuan_open, spriteSets[0]},
-	"sato1_komaru2_0": {fuan_open, spriteSets[0]},
-	"sato1_tukare_0": {fuan_close, spriteSets[0]},
-	"sato1_warai_0": {smile_open, spriteSets[0]},
-	"sato1_warai_1": {smile_open, spriteSets[0]},
+	"sato1_def1_0": {smile_open, spriteSets[0], ep5},
+	"sato1_def2_0": {smile_close, spriteSets[0], ep5},
+	"sato1_ikari_1": {ikari_open, spriteSets[0], ep5},
+	"sato1_komaru_0": {komaru_open, spriteSets[0], ep5},
+	"sato1_komaru2_0": {fuan_open, spriteSets[0], ep5},
+	"sato1_tukare_0": {fuan_close, spriteSets[0], ep5},
+	"sato1_warai_0": {smile_open, spriteSets[0], ep5},
+	"sato1_warai_1": {smile_open, spriteSets[0], ep5},
 
 	// satoshi 2 - baseball
 	// ep5
-	"sato2_def1_0": {smile_open, spriteSets[1]},
-	"sato2_def2_0": {smile_close, spriteSets[1]},
-	"sato2_komaru_0": {fuan_open, spriteSets[1]},
-	"sato2_komaru2_0": {fuan_open, spriteSets[1]},
-	"sato2_tukare_0": {fuan_close, spriteSets[1]},
-	"sato2_warai_1": {smile_open, spriteSets[1]},
+	"sato2_def1_0": {smile_open, spriteSets[1], ep5},
+	"sato2_def2_0": {smile_close, spriteSets[1], ep5},
+	"sato2_komaru_0": {komaru_open, spriteSets[1], ep5},
+	"sato2_komaru2_0": {fuan_open, spriteSets[1], ep5},
+	"sato2_tukare_0": {fuan_close, spriteSets[1], ep5},
+	"sato2_warai_1": {smile_open, spriteSets[1], ep5},
 
 	// teppei
 	// ep5
-	"tetu_1_0": {futeki_open, spriteSets[0]},
+	"tetu_1_0": {futeki_open, spriteSets[0], ep5},
 	// ep6
-	"tetu_2_0": {normal_open, spriteSets[0]},
-	"tetu_3_0": {odoroki_open, spriteSets[0]},
+	"tetu_2_0": {normal_open, spriteSets[0], ep6},
+	"tetu_3_0": {odoroki_open, spriteSets[0], ep6},
 	// ep7
-	"tetu_2_2": {normal_open, spriteSets[0]},
-	"tetu_3_2": {odoroki_open, spriteSets[0]},
+	"tetu_2_2": {normal_open, spriteSets[0], ep7},
+	"tetu_3_2": {odoroki_open, spriteSets[0], ep7},
 	// hou+
-	"tetu_4_2": {smile_open, spriteSets[0]},
-	"tetu_5_0": {smile_open, spriteSets[0]},
+	"tetu_4_2": {smile_open, spriteSets[0], hou},
+	"tetu_5_0": {smile_open, spriteSets[0], hou},
 	
 	// rina 1
 	// rina never appeared in mei
 	// ep6
-	"rina_def_0": {smile_open, spriteSets[0]},
-	"rina_ikari_0": {sinken_open, spriteSets[0]},
-	"rina_warai_0": {smile_open, spriteSets[0]},
+	"rina_def_0": {smile_open, spriteSets[0], ep6},
+	"rina_ikari_0": {ikari_open, spriteSets[0], ep6},
+	"rina_warai_0": {smile_open, spriteSets[0], ep6},
 	// ep7
-	"rina_warai_2": {smile_open, spriteSets[0]},
+	"rina_warai_2": {smile_open, spriteSets[0], ep7},
 
 	// akasaka 1 - casual
 	// ep7
-	"aks1_def_0": {smile_open, spriteSets[0]},
-	"aks1_warai_2": {smile_close, spriteSets[0]},
+	"aks1_def_0": {smile_open, spriteSets[0], ep7},
+	"aks1_warai_2": {smile_close, spriteSets[0], ep7},
 	// ep8
-	"aks1_sakebi_2": {sinken_open, spriteSets[0]},
-	"aks1_shinken_0": {normal_open, spriteSets[0]},
+	"aks1_sakebi_2": {sinken_open, spriteSets[0], ep8},
+	"aks1_shinken_0": {normal_open, spriteSets[0], ep8},
 
 	// akasaka 2 - fighting
 	// ep8
-	"aks2_niyari_0": {smile_open, spriteSets[1]},
-	"aks2_sakebi_2": {sinken_open, spriteSets[1]},
-	"aks2_shinken_0": {normal_open, spriteSets[1]},
+	"aks2_niyari_0": {smile_open, spriteSets[1], ep8},
+	"aks2_sakebi_2": {sinken_open, spriteSets[1], ep8},
+	"aks2_shinken_0": {normal_open, spriteSets[1], ep8},
 
 
 	// hanyuu 1 - formal
 	// ep7
-	"ha1_au_2": {fuan_blush_open, spriteSets[8]},
-	"ha1_def_0": {smile_blush_open, spriteSets[8]},
-	"ha1_def2_0": {normal_blush_open, spriteSets[8]},
-	"ha1_odoroki_2": {odoroki_blush_open, spriteSets[8]},
-	"ha1_warai_2": {smile_blush_close, spriteSets[8]},
-	"ha1_yowaki_0": {normal_blush_open, spriteSets[8]},
+	"ha1_au_2": {tere_blush_open, spriteSets[8], ep7},
+	"ha1_def_0": {smile_blush_open, spriteSets[8], ep7},
+	"ha1_def2_0": {normal_blush_open, spriteSets[8], ep7},
+	"ha1_odoroki_2": {odoroki_blush_open, spriteSets[8], ep7},
+	"ha1_warai_2": {smile_blush_close, spriteSets[8], ep7},
+	"ha1_yowaki_0": {normal_blush_open, spriteSets[8], ep7},
 	// ep8
-	"ha1_muhyou_0": {normal_blush_open, spriteSets[8]},
-	"ha1_sakebi_0": {sinken_blush_open, spriteSets[8]},
-	"ha1_shinken_0": {sinken_open, spriteSets[8]},
+	"ha1_muhyou_0": {normal_blush_open, spriteSets[8], ep8},
+	"ha1_sakebi_0": {sinken_blush_open, spriteSets[8], ep8},
+	"ha1_shinken_0": {sinken_open, spriteSets[8], ep8},
 
 	// hanyuu 2a - school
 	// ep8
-	"ha2a_au_2": {fuan_blush_open, spriteSets[0]},
-	"ha2a_def_0": {smile_blush_open, spriteSets[0]},
-	"ha2a_def2_0": {sinken_blush_open, spriteSets[0]},
-	"ha2a_muhyou_0": {normal_blush_open, spriteSets[0]},
-	"ha2a_odoroki_2": {odoroki_blush_open, spriteSets[0]},
-	"ha2a_sakebi_0": {sinken_blush_open, spriteSets[0]},
-	"ha2a_warai_2": {smile_blush_close, spriteSets[0]},
-	"ha2a_yowaki_0": {normal_blush_open, spriteSets[0]},
+	"ha2a_au_2": {tere_blush_open, spriteSets[0], ep8},
+	"ha2a_def_0": {smile_blush_open, spriteSets[0], ep8},
+	"ha2a_def2_0": {sinken_blush_open, spriteSets[0], ep8},
+	"ha2a_muhyou_0": {normal_blush_open, spriteSets[0], ep8},
+	"ha2a_odoroki_2": {odoroki_blush_open, spriteSets[0], ep8},
+	"ha2a_sakebi_0": {sinken_blush_open, spriteSets[0], ep8},
+	"ha2a_warai_2": {smile_blush_close, spriteSets[0], ep8},
+	"ha2a_yowaki_0": {normal_blush_open, spriteSets[0], ep8},
 
 	// hanyuu 2b - school
 	// ep8
-	"ha2b_def_0": {smile_blush_open, spriteSets[0]},
-	"ha2b_def2_0": {normal_blush_open, spriteSets[0]},
-	"ha2b_warai_2": {smile_blush_close, spriteSets[0]},
+	"ha2b_def_0": {smile_blush_open, spriteSets[0], ep8},
+	"ha2b_def2_0": {normal_blush_open, spriteSets[0], ep8},
+	"ha2b_warai_2": {smile_blush_close, spriteSets[0], ep8},
 
 	// hanyuu 3a - school
 	// ep8
-	"ha3a_au_2": {fuan_blush_open, spriteSets[0]},
-	"ha3a_def_0": {smile_blush_open, spriteSets[0]},
-	"ha3a_def2_0": {normal_blush_open, spriteSets[0]},
-	"ha3a_odoroki_2": {odoroki_blush_open, spriteSets[0]},
-	"ha3a_warai_2": {smile_blush_close, spriteSets[0]},
-	"ha3a_yowaki_0": {normal_blush_open, spriteSets[0]},
+	"ha3a_au_2": {tere_blush_open, spriteSets[0], ep8},
+	"ha3a_def_0": {smile_blush_open, spriteSets[0], ep8},
+	"ha3a_def2_0": {normal_blush_open, spriteSets[0], ep8},
+	"ha3a_odoroki_2": {odoroki_blush_open, spriteSets[0], ep8},
+	"ha3a_warai_2": {smile_blush_close, spriteSets[0], ep8},
+	"ha3a_yowaki_0": {normal_blush_open, spriteSets[0], ep8},
 	// rei
-	"ha3a_shinken_0": {sinken_blush_open, spriteSets[0]},
+	"ha3a_shinken_0": {sinken_blush_open, spriteSets[0], rei},
 
 	// hanyuu 5 - costume
 	// hou+
-	"ha5_muhyou_0": {normal_blush_open, spriteSets[31]},
-	"ha5_odoroki_2": {odoroki_blush_open, spriteSets[31]},
-	"ha5_shinken_0": {sinken_blush_open, spriteSets[31]},
+	"ha5_muhyou_0": {normal_blush_open, spriteSets[31], hou},
+	"ha5_odoroki_2": {odoroki_blush_open, spriteSets[31], hou},
+	"ha5_shinken_0": {sinken_blush_open, spriteSets[31], hou},
 
 	// hanyuu 6 - angel mort
 	// hou+
-	"ha6_au_2": {fuan_blush_open, spriteSets[5]},
+	"ha6_au_2": {tere_blush_open, spriteSets[5], hou},
 
 	// okonogi 1 - casual
 	// okonogi never appeared in mei
 	// ep7
-	"oko_def_0": {smile_open, spriteSets[0]},
+	"oko_def_0": {smile_open, spriteSets[0], ep7},
 	// ep8
-	"oko_kumon_0": {futeki_open, spriteSets[0]},
-	"oko_niyari_2": {sinken_open, spriteSets[0]},
-	"oko_odoroki_0": {normal_open, spriteSets[0]},
-	"oko_sakebi_0": {fuan_open, spriteSets[0]},
+	"oko_kumon_0": {futeki_open, spriteSets[0], ep8},
+	"oko_niyari_2": {sinken_open, spriteSets[0], ep8},
+	"oko_odoroki_0": {normal_open, spriteSets[0], ep8},
+	"oko_sakebi_0": {fuan_open, spriteSets[0], ep8},
 
 	// okonogi 2 - army
 	// ep8
-	"oko2_def_0": {smile_open, spriteSets[1]},
+	"oko2_def_0": {smile_open, spriteSets[1], ep8},
 
 	// okonogi 3 - army?
 	// rei
-	"oko3_def_0": {smile_open, spriteSets[2]},
-	"oko3_kumon_2": {futeki_open, spriteSets[2]},
-	"oko3_niyari_2": {sinken_open, spriteSets[2]},
-	"oko3_odoroki_0": {normal_open, spriteSets[2]},
-	"oko3_sakebi_1": {fuan_open, spriteSets[2]},
+	"oko3_def_0": {smile_open, spriteSets[2], rei},
+	"oko3_kumon_2": {futeki_open, spriteSets[2], rei},
+	"oko3_niyari_2": {sinken_open, spriteSets[2], rei},
+	"oko3_odoroki_0": {normal_open, spriteSets[2], rei},
+	"oko3_sakebi_1": {fuan_open, spriteSets[2], rei},
 
 	// kameda 1a - speedo
 	// kameda never appeared in mei
 	// hou+
-	"kameda1a_def_0": {smile_open, spriteSets[0]},
-	"kameda1a_shinken_0": {normal_open, spriteSets[0]},
-	"kameda1a_warai_2": {smile_close, spriteSets[0]},
-	"kameda1b_odoroki_2": {odoroki_open, spriteSets[0]},
+	"kameda1a_def_0": {smile_open, spriteSets[0], hou},
+	"kameda1a_shinken_0": {normal_open, spriteSets[0], hou},
+	"kameda1a_warai_2": {smile_close, spriteSets[0], hou},
+	"kameda1b_odoroki_2": {odoroki_open, spriteSets[0], hou},
 
 	// mo 1-3 & mura
 	// i have no idea who these characters are
 	// hou+
-	"mo1_01_0": {normal_open, spriteSets[0]},
-	"mo2_01_0": {smile_open, spriteSets[0]},
-	"mo3_01_0": {fuan_open, spriteSets[0]},
-	"mura_01_0": {normal_open, spriteSets[0]},
+	"mo1_01_0": {normal_open, spriteSets[0], hou},
+	"mo2_01_0": {smile_open, spriteSets[0], hou},
+	"mo3_01_0": {fuan_open, spriteSets[0], hou},
+	"mura_01_0": {normal_open, spriteSets[0], hou},
 
 	// tamura 1
 	// hou+
-	"tamura1a_01_0": {normal_blush_open, spriteSets[0]},
-	"tamura1a_02_2": {fuan_blush_open, spriteSets[0]},
-	"tamura1a_03_2": {fuan_blush_open, spriteSets[0]},
-	"tamura1a_04_2": {fuan_blush_close, spriteSets[0]},
-	"tamura1a_05_0": {normal_blush_close, spriteSets[0]},
-	"tamura1a_06_0": {futeki_blush_close, spriteSets[0]},
-	"tamura1a_07_2": {odoroki_blush_open, spriteSets[0]},
-	"tamura1a_08_0": {sinken_blush_open, spriteSets[0]},
-	"tamura1a_09_2": {odoroki_blush_open, spriteSets[0]},
-	"tamura1a_10_2": {odoroki_blush_close, spriteSets[0]},
-	"tamura1a_11_2": {fuan_open, spriteSets[0]},
+	"tamura1a_01_0": {normal_blush_open, spriteSets[0], hou},
+	"tamura1a_02_2": {fuan_blush_open, spriteSets[0], hou},
+	"tamura1a_03_2": {fuan_blush_open, spriteSets[0], hou},
+	"tamura1a_04_2": {fuan_blush_close, spriteSets[0], hou},
+	"tamura1a_05_0": {normal_blush_close, spriteSets[0], hou},
+	"tamura1a_06_0": {futeki_blush_close, spriteSets[0], hou},
+	"tamura1a_07_2": {odoroki_blush_open, spriteSets[0], hou},
+	"tamura1a_08_0": {sinken_blush_open, spriteSets[0], hou},
+	"tamura1a_09_2": {odoroki_blush_open, spriteSets[0], hou},
+	"tamura1a_10_2": {odoroki_blush_close, spriteSets[0], hou},
+	"tamura1a_11_2": {fuan_open, spriteSets[0], hou},
 
 	// tamura 2
 	// hou+
-	"tamura2a_10_2": {odoroki_blush_close, spriteSets[2]},
+	"tamura2a_10_2": {odoroki_blush_close, spriteSets[2], hou},
 
 	// une 1a
 	// hou+
-	"une1a_01_0": {normal_open, spriteSets[0]},
-	"une1a_02_2": {odoroki_open, spriteSets[0]},
-	"une1a_03_0": {normal_close, spriteSets[0]},
-	"une1a_04_2": {smile_open, spriteSets[0]},
-	"une1a_05_0": {fuan_close, spriteSets[0]},
-	"une1a_06_0": {normal_open, spriteSets[0]},
-	"une1a_07_1": {fuan_blush_open, spriteSets[0]},
-	"une1a_09_2": {odoroki_blush_open, spriteSets[0]},
-	"une1a_10_2": {L5_blush_close, spriteSets[0]},
-	"une1a_11_1": {odoroki_open, spriteSets[0]},
-	"une1a_12_0": {futeki_blush_open, spriteSets[0]},
-	"une1a_14_2": {futeki_blush_open, spriteSets[0]},
-	"une1a_15_0": {L5_blush_open, spriteSets[0]},
-	"une1b_01_0": {normal_open, spriteSets[0]},
-	"une1b_04_2": {smile_open, spriteSets[0]},
-	"une1b_06_0": {normal_open, spriteSets[0]},
-	"une1b_07_1": {fuan_blush_open, spriteSets[0]},
-	"une1b_09_2": {odoroki_blush_open, spriteSets[0]},
-	"une1b_10_2": {L5_blush_close, spriteSets[0]},
-	"une1b_11_1": {odoroki_open, spriteSets[0]},
-	"une1b_12_0": {futeki_blush_open, spriteSets[0]},
-	"une1b_13_2": {futeki_blush_open, spriteSets[0]},
-	"une2b_10_2": {L5_blush_close, spriteSets[0]},
-	"une3a_01_0": {normal_open, spriteSets[0]},
-	"une3a_02_2": {odoroki_open, spriteSets[0]},
-	"une3a_05_0": {fuan_close, spriteSets[0]},
-	"une3a_06_0": {normal_open, spriteSets[0]},
-	"une3a_07_1": {fuan_blush_open, spriteSets[0]},
-	"une3a_08_2": {odoroki_open, spriteSets[0]},
-	"une3a_09_2": {odoroki_blush_open, spriteSets[0]},
-	"une3a_10_2": {L5_blush_close, spriteSets[0]},
-	"une3a_11_1": {odoroki_open, spriteSets[0]},
-	"une3a_12_0": {futeki_blush_open, spriteSets[0]},
-	"une3a_13_2": {futeki_blush_open, spriteSets[0]},
-	"une3a_14_2": {futeki_blush_open, spriteSets[0]},
-	"une3a_15_0": {L5_blush_open, spriteSets[0]},
-	"une4a_01_0": {normal_open, spriteSets[0]},
-	"une4a_02_2": {odoroki_open, spriteSets[0]},
-	"une4a_09_2": {odoroki_blush_open, spriteSets[0]},
+	"une1a_01_0": {normal_open, spriteSets[0], hou},
+	"une1a_02_2": {odoroki_open, spriteSets[0], hou},
+	"une1a_03_0": {normal_close, spriteSets[0], hou},
+	"une1a_04_2": {smile_open, spriteSets[0], hou},
+	"une1a_05_0": {fuan_close, spriteSets[0], hou},
+	"une1a_06_0": {normal_open, spriteSets[0], hou},
+	"une1a_07_1": {fuan_blush_open, spriteSets[0], hou},
+	"une1a_09_2": {odoroki_blush_open, spriteSets[0], hou},
+	"une1a_10_2": {L5_blush_close, spriteSets[0], hou},
+	"une1a_11_1": {odoroki_open, spriteSets[0], hou},
+	"une1a_12_0": {futeki_blush_open, spriteSets[0], hou},
+	"une1a_14_2": {futeki_blush_open, spriteSets[0], hou},
+	"une1a_15_0": {L5_blush_open, spriteSets[0], hou},
+	"une1b_01_0": {normal_open, spriteSets[0], hou},
+	"une1b_04_2": {smile_open, spriteSets[0], hou},
+	"une1b_06_0": {normal_open, spriteSets[0], hou},
+	"une1b_07_1": {fuan_blush_open, spriteSets[0], hou},
+	"une1b_09_2": {odoroki_blush_open, spriteSets[0], hou},
+	"une1b_10_2": {L5_blush_close, spriteSets[0], hou},
+	"une1b_11_1": {odoroki_open, spriteSets[0], hou},
+	"une1b_12_0": {futeki_blush_open, spriteSets[0], hou},
+	"une1b_13_2": {futeki_blush_open, spriteSets[0], hou},
+	"une2b_10_2": {L5_blush_close, spriteSets[0], hou},
+	"une3a_01_0": {normal_open, spriteSets[0], hou},
+	"une3a_02_2": {odoroki_open, spriteSets[0], hou},
+	"une3a_05_0": {fuan_close, spriteSets[0], hou},
+	"une3a_06_0": {normal_open, spriteSets[0], hou},
+	"une3a_07_1": {fuan_blush_open, spriteSets[0], hou},
+	"une3a_08_2": {odoroki_open, spriteSets[0], hou},
+	"une3a_09_2": {odoroki_blush_open, spriteSets[0], hou},
+	"une3a_10_2": {L5_blush_close, spriteSets[0], hou},
+	"une3a_11_1": {odoroki_open, spriteSets[0], hou},
+	"une3a_12_0": {futeki_blush_open, spriteSets[0], hou},
+	"une3a_13_2": {futeki_blush_open, spriteSets[0], hou},
+	"une3a_14_2": {futeki_blush_open, spriteSets[0], hou},
+	"une3a_15_0": {L5_blush_open, spriteSets[0], hou},
+	"une4a_01_0": {normal_open, spriteSets[0], hou},
+	"une4a_02_2": {odoroki_open, spriteSets[0], hou},
+	"une4a_09_2": {odoroki_blush_open, spriteSets[0], hou},
 }
 
 
@@ -1437,6 +1551,68 @@ func GetFolder(key string) string {
 	return selected
 }
 
+// Converts a sprite key into the character whose selection applies to it
+func GetCharacter(key string) string {
+	var selected string
+	longest := 0
+	for prefix, character := range CharacterMap {
+		if strings.HasPrefix(key, prefix) && len(prefix) > longest {
+			selected = character
+			longest = len(prefix)
+		}
+	}
+	if selected == "" {
+		log.Printf("WARNING: No character mapping found for key: %s", key)
+		selected = "unknown"
+	}
+	return selected
+}
+
+// Returns the chapter the selected game exe belongs to, or "" if unknown
+func ChapterForGame(gamePath string) string {
+	return GameChapters[filepath.Base(gamePath)]
+}
+
+// Returns the chapter a sprite key first appears in
+func SpriteChapter(key string) string {
+	info, ok := RawGameSprites[key]
+	if !ok || len(info) < 3 {
+		return noChapter
+	}
+	return info[2]
+}
+
+// Reports whether a sprite can show up in the given chapter. Sprites
+// first seen in an earlier chapter stay relevant in later ones, and
+// sprites or chapters we know nothing about are always kept.
+func SpriteInChapter(key, chapter string) bool {
+	first := SpriteChapter(key)
+	if chapter == noChapter || first == noChapter {
+		return true
+	}
+	return chapterIndex(first) <= chapterIndex(chapter)
+}
+
+func chapterIndex(chapter string) int {
+	for i, c := range Chapters {
+		if c == chapter {
+			return i
+		}
+	}
+	return len(Chapters)
+}
+
+// Counts the sprites of a character that can show up in the given chapter
+func CountSpritesInChapter(character, chapter string) int {
+	n := 0
+	for key := range RawGameSprites {
+		if GetCharacter(key) == character && SpriteInChapter(key, chapter) {
+			n++
+		}
+	}
+	return n
+}
+
 func ResolveSpritePathWithSelection(key string, selectedVariants map[string]string) string {
     info, ok := RawGameSprites[key]
     if !ok {
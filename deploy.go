@@ -0,0 +1,150 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// how deployFile put a file in place
+const (
+	deployHardlink = "hardlink"
+	deployReflink  = "reflink"
+	deployCopy     = "copy"
+)
+
+// Replaces dst with src. The new file is made next to dst as a hardlink
+// when allowed, else as a reflink where the filesystem can share blocks,
+// else as a plain copy, and then renamed over dst, so dst is never
+// missing or half written and the other names of a hardlinked file keep
+// their contents. Only files nothing ever writes to in place (the sprite
+// cache) may be hardlinked.
+func deployFile(src, dst string, hardlink bool) (string, error) {
+	tmp := dst + ".tmp"
+	os.Remove(tmp)
+
+	method := deployCopy
+	switch {
+	case hardlink && os.Link(src, tmp) == nil:
+		method = deployHardlink
+	case reflink(src, tmp) == nil:
+		method = deployReflink
+	default:
+		if err := copyFile(src, tmp); err != nil {
+			os.Remove(tmp)
+			return "", err
+		}
+	}
+	err := os.Rename(tmp, dst)
+	// renaming onto another name of the same file leaves tmp behind
+	os.Remove(tmp)
+	if err != nil {
+		return "", err
+	}
+	return method, nil
+}
+
+const spriteCacheDir = "cache"
+
+// SpriteCache keeps processed sprites by content hash under
+// cache/objects, with an index from the inputs that produced them, so a
+// Mei face drawn onto the same canvas the same way is only processed
+// once and every game key using it can share one file. Whatever no
+// history state refers to is evicted as history drops states.
+type SpriteCache struct {
+	Dir   string
+	mu    sync.Mutex
+	index map[string]string // input key → content hash
+}
+
+func openSpriteCache(dir string) *SpriteCache {
+	c := &SpriteCache{Dir: dir, index: make(map[string]string)}
+	if data, err := os.ReadFile(filepath.Join(dir, "index.json")); err == nil {
+		json.Unmarshal(data, &c.index)
+	}
+	return c
+}
+
+func hashBytes(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Returns the cache key of everything processSprite's output depends on
+func spriteCacheKey(original, replacement []byte, character string, cal Calibration, effects []string) string {
+	return hashBytes([]byte(fmt.Sprintf("%s|%s|%s|%+v|%q",
+		hashBytes(original), hashBytes(replacement), cal.anchor(character), cal, effects)))
+}
+
+func (c *SpriteCache) objectPath(sum string) string {
+	return filepath.Join(c.Dir, "objects", sum[:2], sum+".png")
+}
+
+// Returns the path of the cached sprite for a key. An object that no
+// longer matches its hash was rewritten through a hardlink, e.g. by a
+// game installer, and is dropped.
+func (c *SpriteCache) Get(key string) (string, bool) {
+	c.mu.Lock()
+	sum, ok := c.index[key]
+	c.mu.Unlock()
+	if !ok {
+		return "", false
+	}
+	path := c.objectPath(sum)
+	if actual, err := hashFile(path); err != nil || actual != sum {
+		os.Remove(path)
+		return "", false
+	}
+	return path, true
+}
+
+// Stores a processed sprite under its content hash and returns its path
+func (c *SpriteCache) Put(key string, data []byte) (string, error) {
+	sum := hashBytes(data)
+	path := c.objectPath(sum)
+	if _, err := os.Stat(path); err != nil {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return "", err
+		}
+		// written aside and renamed so a half-written object is never found
+		tmp, err := os.CreateTemp(filepath.Dir(path), "*.tmp")
+		if err != nil {
+			return "", err
+		}
+		_, err = tmp.Write(data)
+		if closeErr := tmp.Close(); err == nil {
+			err = closeErr
+		}
+		if err == nil {
+			err = os.Chmod(tmp.Name(), 0644)
+		}
+		if err == nil {
+			err = os.Rename(tmp.Name(), path)
+		}
+		if err != nil {
+			os.Remove(tmp.Name())
+			return "", err
+		}
+	}
+	c.mu.Lock()
+	c.index[key] = sum
+	c.mu.Unlock()
+	return path, nil
+}
+
+func (c *SpriteCache) Save() error {
+	c.mu.Lock()
+	data, err := json.MarshalIndent(c.index, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(c.Dir, "index.json"), data, 0644)
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+// PackVariants is Mei folder → variant folders installed on disk,
+// filled in from the sprite pack at startup
+var PackVariants = make(map[string][]string)
+
+var variantDir = regexp.MustCompile(`^v\d{3}$`)
+
+// Scans the Mei sprite pack for the vNNN folders each character has
+func ScanSpritePack(root string) map[string][]string {
+	found := make(map[string][]string)
+
+	folders, err := os.ReadDir(root)
+	if err != nil {
+		return found
+	}
+	for _, f := range folders {
+		if !f.IsDir() {
+			continue
+		}
+		variants, err := os.ReadDir(filepath.Join(root, f.Name()))
+		if err != nil {
+			continue
+		}
+		for _, v := range variants {
+			if v.IsDir() && variantDir.MatchString(v.Name()) {
+				found[f.Name()] = append(found[f.Name()], v.Name())
+			}
+		}
+		sort.Strings(found[f.Name()])
+	}
+	return found
+}
+
+// Returns the Mei folder a character's sprites live in
+func meiFolder(character string) string {
+	if folder, ok := CharacterFolders[character]; ok {
+		return folder
+	}
+	return character
+}
+
+func variantInstalled(folder, variant string) bool {
+	for _, v := range PackVariants[folder] {
+		if v == variant {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the catalogued outfits that are installed, followed by any
+// installed variant folder the catalogue doesn't know yet
+func installedOutfits(character string) []Outfit {
+	folder := meiFolder(character)
+	catalogued := make(map[string]bool)
+
+	var outfits []Outfit
+	for _, o := range Characters[character].OutfitsMei {
+		catalogued[o.SpriteSet] = true
+		if variantInstalled(folder, o.SpriteSet) {
+			outfits = append(outfits, o)
+		}
+	}
+	for _, v := range PackVariants[folder] {
+		if !catalogued[v] {
+			outfits = append(outfits, Outfit{"Unknown " + v, v})
+		}
+	}
+	return outfits
+}
+
+// Reports whether a menu option is a catalogued outfit that isn't installed
+func outfitMissing(character, name string) bool {
+	for _, o := range Characters[character].OutfitsMei {
+		if o.Name == name {
+			return !variantInstalled(meiFolder(character), o.SpriteSet)
+		}
+	}
+	return false
+}
+
+// Returns the outfit "Best Match" dresses a character in: the first
+// installed one the content filters allow
+func bestMatch(character string, filters map[string]bool) (Outfit, bool) {
+	if outfits := filterOutfits(installedOutfits(character), filters); len(outfits) > 0 {
+		return outfits[0], true
+	}
+	return Outfit{}, false
+}
+
+// Returns the variant to use when nothing better is known
+func defaultVariant(folder string) string {
+	if variants := PackVariants[folder]; len(variants) > 0 {
+		return variants[0]
+	}
+	return spriteSets[0]
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"math/rand"
+)
+
+// post-processing effects a sprite can be run through before it's written
+const (
+	effectGrayscale  = "Grayscale"
+	effectSepia      = "Sepia"
+	effectHueShift   = "Hue Shift"
+	effectPixelate   = "Pixelate"
+	effectSilhouette = "Silhouette"
+	effectMirror     = "Mirror"
+	effectRandom     = "Random Effect"
+)
+
+var imageEffects = []string{effectGrayscale, effectSepia, effectHueShift, effectPixelate, effectSilhouette, effectMirror}
+
+// effects as listed in the effect menus
+var effectChoices = append(imageEffects[:len(imageEffects):len(imageEffects)], effectRandom)
+
+// EffectFunc changes a decoded sprite in place or returns a new one
+type EffectFunc func(img *image.NRGBA) *image.NRGBA
+
+var effectFuncs = map[string]EffectFunc{
+	effectGrayscale:  grayscale,
+	effectSepia:      sepia,
+	effectHueShift:   func(img *image.NRGBA) *image.NRGBA { return hueShift(img, 180) },
+	effectPixelate:   func(img *image.NRGBA) *image.NRGBA { return pixelate(img, 8) },
+	effectSilhouette: silhouette,
+	effectMirror:     mirror,
+}
+
+// Replaces Random Effect in a chain with one of the real effects
+func resolveEffects(rng *rand.Rand, chain []string) []string {
+	resolved := make([]string, 0, len(chain))
+	for _, name := range chain {
+		if name == effectRandom {
+			name = imageEffects[rng.Intn(len(imageEffects))]
+		}
+		resolved = append(resolved, name)
+	}
+	return resolved
+}
+
+// Runs a sprite through an effect chain, skipping unknown names
+func applyEffects(img *image.NRGBA, chain []string) *image.NRGBA {
+	for _, name := range chain {
+		if f, ok := effectFuncs[name]; ok {
+			img = f(img)
+		}
+	}
+	return img
+}
+
+// Toggles an effect in a chain, keeping the chain in menu order
+func toggleEffect(chain []string, effect string) []string {
+	on := make(map[string]bool)
+	for _, name := range chain {
+		on[name] = true
+	}
+	on[effect] = !on[effect]
+
+	toggled := []string{}
+	for _, name := range effectChoices {
+		if on[name] {
+			toggled = append(toggled, name)
+		}
+	}
+	return toggled
+}
+
+// Returns the effect chain for a character: its own if it has one,
+// otherwise the global chain stored under ""
+func effectsFor(effects map[string][]string, character string) []string {
+	if chain, ok := effects[character]; ok {
+		return chain
+	}
+	return effects[""]
+}
+
+func mapPixels(img *image.NRGBA, f func(c color.NRGBA) color.NRGBA) *image.NRGBA {
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			img.SetNRGBA(x, y, f(img.NRGBAAt(x, y)))
+		}
+	}
+	return img
+}
+
+func luma(c color.NRGBA) float64 {
+	return 0.299*float64(c.R) + 0.587*float64(c.G) + 0.114*float64(c.B)
+}
+
+func grayscale(img *image.NRGBA) *image.NRGBA {
+	return mapPixels(img, func(c color.NRGBA) color.NRGBA {
+		l := uint8(math.Round(luma(c)))
+		return color.NRGBA{l, l, l, c.A}
+	})
+}
+
+func sepia(img *image.NRGBA) *image.NRGBA {
+	return mapPixels(img, func(c color.NRGBA) color.NRGBA {
+		r, g, b := float64(c.R), float64(c.G), float64(c.B)
+		return color.NRGBA{
+			R: clampByte(0.393*r + 0.769*g + 0.189*b),
+			G: clampByte(0.349*r + 0.686*g + 0.168*b),
+			B: clampByte(0.272*r + 0.534*g + 0.131*b),
+			A: c.A,
+		}
+	})
+}
+
+// Rotates every colour's hue by the given number of degrees
+func hueShift(img *image.NRGBA, degrees float64) *image.NRGBA {
+	return mapPixels(img, func(c color.NRGBA) color.NRGBA {
+		h, s, l := rgbToHSL(c)
+		h = math.Mod(h+degrees, 360)
+		r, g, b := hslToRGB(h, s, l)
+		return color.NRGBA{r, g, b, c.A}
+	})
+}
+
+// Averages size×size blocks into single colours
+func pixelate(img *image.NRGBA, size int) *image.NRGBA {
+	b := img.Bounds()
+	for by := b.Min.Y; by < b.Max.Y; by += size {
+		for bx := b.Min.X; bx < b.Max.X; bx += size {
+			block := image.Rect(bx, by, bx+size, by+size).Intersect(b)
+
+			var r, g, bl, a, n int
+			for y := block.Min.Y; y < block.Max.Y; y++ {
+				for x := block.Min.X; x < block.Max.X; x++ {
+					c := img.NRGBAAt(x, y)
+					r += int(c.R) * int(c.A)
+					g += int(c.G) * int(c.A)
+					bl += int(c.B) * int(c.A)
+					a += int(c.A)
+					n++
+				}
+			}
+
+			avg := color.NRGBA{}
+			if a > 0 {
+				avg = color.NRGBA{uint8(r / a), uint8(g / a), uint8(bl / a), uint8(a / n)}
+			}
+			for y := block.Min.Y; y < block.Max.Y; y++ {
+				for x := block.Min.X; x < block.Max.X; x++ {
+					img.SetNRGBA(x, y, avg)
+				}
+			}
+		}
+	}
+	return img
+}
+
+// Paints the whole figure black, keeping its outline
+func silhouette(img *image.NRGBA) *image.NRGBA {
+	return mapPixels(img, func(c color.NRGBA) color.NRGBA {
+		return color.NRGBA{0, 0, 0, c.A}
+	})
+}
+
+// Flips the sprite horizontally
+func mirror(img *image.NRGBA) *image.NRGBA {
+	b := img.Bounds()
+	out := image.NewNRGBA(b)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			out.SetNRGBA(b.Max.X-1-(x-b.Min.X), y, img.NRGBAAt(x, y))
+		}
+	}
+	return out
+}
+
+func clampByte(v float64) uint8 {
+	if v > 255 {
+		return 255
+	}
+	if v < 0 {
+		return 0
+	}
+	return uint8(math.Round(v))
+}
+
+func rgbToHSL(c color.NRGBA) (h, s, l float64) {
+	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
+	max := math.Max(r, math.Max(g, b))
+	min := math.Min(r, math.Min(g, b))
+	l = (max + min) / 2
+	if max == min {
+		return 0, 0, l
+	}
+
+	d := max - min
+	if l > 0.5 {
+		s = d / (2 - max - min)
+	} else {
+		s = d / (max + min)
+	}
+	switch max {
+	case r:
+		h = math.Mod((g-b)/d+6, 6)
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+	return h * 60, s, l
+}
+
+func hslToRGB(h, s, l float64) (uint8, uint8, uint8) {
+	c := (1 - math.Abs(2*l-1)) * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := l - c/2
+
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+	return clampByte((r + m) * 255), clampByte((g + m) * 255), clampByte((b + m) * 255)
+}
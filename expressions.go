@@ -0,0 +1,148 @@
+package main
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Expression is a Mei sprite name split into its parts:
+// <emotion>[_blush]_<open|close>
+type Expression struct {
+	Emotion string
+	Blush   bool
+	State   string // open or close (half for synthesized mouth frames)
+}
+
+// expression randomization modes, chosen per character
+const (
+	exprOriginal     = "Original Expressions"
+	exprSameFamily   = "Random (Same Emotion Family)"
+	exprBlushAndEyes = "Random Blush & Eyes Only"
+	exprFullyRandom  = "Fully Random Expressions"
+
+	// what exprBlushAndEyes becomes on lip-synced poses, where the
+	// open/close state belongs to the mouth frames
+	exprBlushOnly = "Random Blush Only"
+)
+
+var expressionModes = []string{exprOriginal, exprSameFamily, exprBlushAndEyes, exprFullyRandom}
+
+// Splits a Mei expression name into emotion, blush and state
+func ParseExpression(name string) (Expression, bool) {
+	var e Expression
+
+	i := strings.LastIndex(name, "_")
+	if i == -1 {
+		return e, false
+	}
+	e.State = name[i+1:]
+	e.Emotion = name[:i]
+	if e.State != "open" && e.State != "close" && e.State != "half" {
+		return e, false
+	}
+	if strings.HasSuffix(e.Emotion, "_blush") {
+		e.Blush = true
+		e.Emotion = strings.TrimSuffix(e.Emotion, "_blush")
+	}
+	return e, e.Emotion != ""
+}
+
+func (e Expression) String() string {
+	s := e.Emotion
+	if e.Blush {
+		s += "_blush"
+	}
+	return s + "_" + e.State
+}
+
+// Lists the expressions a Mei variant folder actually has
+func variantExpressions(folder, variant string) []string {
+	files, err := os.ReadDir(filepath.Join("sprites", "mei", folder, variant))
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, f := range files {
+		if !f.IsDir() && filepath.Ext(f.Name()) == ".png" {
+			names = append(names, strings.TrimSuffix(f.Name(), ".png"))
+		}
+	}
+	return names
+}
+
+// Re-rolls an expression according to the character's expression mode,
+// only picking faces the variant folder has. Anything that can't be
+// parsed or has no candidates keeps the original expression.
+func randomizeExpression(rng *rand.Rand, folder, variant, expression, mode string) string {
+	if mode == "" || mode == exprOriginal {
+		return expression
+	}
+
+	available := variantExpressions(folder, variant)
+	if mode == exprFullyRandom {
+		if len(available) == 0 {
+			return expression
+		}
+		return available[rng.Intn(len(available))]
+	}
+
+	orig, ok := ParseExpression(expression)
+	if !ok {
+		return expression
+	}
+
+	taxonomy := LoadTaxonomy(folder)
+	orig.Emotion = drawnEmotion(taxonomy, available, expression)
+
+	var candidates []string
+	seen := make(map[string]bool)
+	for _, name := range available {
+		e, ok := ParseExpression(name)
+		if !ok {
+			continue
+		}
+		switch mode {
+		case exprSameFamily:
+			if taxonomy.Family(e.Emotion) == taxonomy.Family(orig.Emotion) {
+				candidates = append(candidates, name)
+			}
+		case exprBlushAndEyes:
+			if e.Emotion == orig.Emotion {
+				candidates = append(candidates, name)
+			}
+		case exprBlushOnly:
+			// every frame of a pose has to draw from the same list, so
+			// candidates are faces without their state
+			e.State = orig.State
+			if e.Emotion == orig.Emotion && !seen[e.String()] {
+				seen[e.String()] = true
+				candidates = append(candidates, e.String())
+			}
+		}
+	}
+
+	if len(candidates) == 0 {
+		return expression
+	}
+	return candidates[rng.Intn(len(candidates))]
+}
+
+// Returns the emotion a face actually resolves to in a variant: the
+// first one along its taxonomy fallbacks that the variant draws
+func drawnEmotion(taxonomy Taxonomy, available []string, expression string) string {
+	drawn := make(map[string]bool)
+	for _, name := range available {
+		if e, ok := ParseExpression(name); ok {
+			drawn[e.Emotion] = true
+		}
+	}
+	for _, candidate := range taxonomy.Fallbacks(expression) {
+		if e, ok := ParseExpression(candidate); ok && drawn[e.Emotion] {
+			return e.Emotion
+		}
+	}
+	e, _ := ParseExpression(expression)
+	return e.Emotion
+}
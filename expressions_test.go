@@ -0,0 +1,75 @@
+package main
+
+import (
+	"math/rand"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// Makes sprites/mei/<folder>/<variant> with the given faces in the
+// current directory
+func meiVariant(t *testing.T, folder, variant string, faces ...string) {
+	t.Helper()
+	for _, face := range faces {
+		writeSprite(t, filepath.Join("sprites", "mei", folder, variant, face+".png"), face)
+	}
+}
+
+func TestRandomizeExpression(t *testing.T) {
+	t.Chdir(t.TempDir())
+	ResetTaxonomy()
+	t.Cleanup(ResetTaxonomy)
+	faces := []string{"smile_open", "smile_close", "smile_blush_open", "smile_blush_close", "fuan_open", "futeki_open"}
+	meiVariant(t, "test", "v001", faces...)
+
+	tests := []struct {
+		mode       string
+		expression string
+		want       []string // every face the mode may pick
+	}{
+		{exprOriginal, "smile_open", []string{"smile_open"}},
+		{exprSameFamily, "smile_open", []string{"smile_open", "smile_close", "smile_blush_open", "smile_blush_close"}},
+		{exprSameFamily, "sinken_open", []string{"futeki_open"}},
+		{exprBlushAndEyes, "smile_close", []string{"smile_open", "smile_close", "smile_blush_open", "smile_blush_close"}},
+		{exprBlushOnly, "smile_half", []string{"smile_half", "smile_blush_half"}},
+		{exprFullyRandom, "smile_open", faces},
+		// unparsable or without candidates: kept
+		{exprBlushAndEyes, "weird", []string{"weird"}},
+		{exprBlushAndEyes, "normal_open", []string{"normal_open"}},
+	}
+	for _, tt := range tests {
+		seen := make(map[string]bool)
+		for seed := int64(0); seed < 64; seed++ {
+			got := randomizeExpression(rand.New(rand.NewSource(seed)), "test", "v001", tt.expression, tt.mode)
+			if !slices.Contains(tt.want, got) {
+				t.Errorf("%s on %s picked %q, want one of %v", tt.mode, tt.expression, got, tt.want)
+			}
+			seen[got] = true
+		}
+		if len(seen) != len(tt.want) {
+			t.Errorf("%s on %s only picked %v of %v", tt.mode, tt.expression, seen, tt.want)
+		}
+	}
+}
+
+func TestParseExpression(t *testing.T) {
+	tests := []struct {
+		name string
+		want Expression
+		ok   bool
+	}{
+		{"smile_blush_open", Expression{"smile", true, "open"}, true},
+		{"futeki_close", Expression{"futeki", false, "close"}, true},
+		{"fuan_half", Expression{"fuan", false, "half"}, true},
+		{"smile_wide", Expression{}, false},
+		{"_open", Expression{}, false},
+		{"normal", Expression{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseExpression(tt.name)
+		if ok != tt.ok || ok && (got != tt.want || got.String() != tt.name) {
+			t.Errorf("ParseExpression(%q) = %+v, %v, want %+v, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,424 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// number of sprite folder states kept for undo and redo
+const historyLimit = 10
+
+// Manifest is one state of the sprite folder: the content hash of every
+// sprite, with the files themselves in the sprite cache's object store
+type Manifest struct {
+	Time       time.Time         `json:"time"`
+	Files      map[string]string `json:"files"`                // key → content hash
+	Run        *RunLog           `json:"run,omitempty"`        // the run that left it, nil for anything else
+	Output     string            `json:"output,omitempty"`     // output mode the folder was in
+	Randomized bool              `json:"randomized,omitempty"` // overlay modes: the randomized set was in place
+}
+
+// History is the list of manifests a sprite folder went through, kept
+// next to it as sprite_history
+type History struct {
+	Dir      string   `json:"-"`
+	Entries  []string `json:"entries"`  // manifest files, oldest first
+	Position int      `json:"position"` // entry the sprite folder was last put in
+}
+
+func historyDir(spriteDir string) string { return spriteDir + "_history" }
+
+func loadHistory(dir string) *History {
+	h := &History{Dir: dir, Position: -1}
+	if data, err := os.ReadFile(filepath.Join(dir, "history.json")); err == nil {
+		json.Unmarshal(data, h)
+	}
+	return h
+}
+
+func (h *History) Save() error {
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(h.Dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(h.Dir, "history.json"), data, 0644)
+}
+
+func (h *History) manifest(i int) (Manifest, error) {
+	var man Manifest
+	if i < 0 || i >= len(h.Entries) {
+		return man, errors.New("no such state")
+	}
+	data, err := os.ReadFile(filepath.Join(h.Dir, h.Entries[i]))
+	if err != nil {
+		return man, err
+	}
+	err = json.Unmarshal(data, &man)
+	return man, err
+}
+
+// Records a new state after the current one, dropping the states that
+// could have been redone and the oldest beyond the limit, and evicting
+// what only they kept in the cache
+func (h *History) Push(man Manifest, cache *SpriteCache) error {
+	data, err := json.Marshal(man)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(h.Dir, 0755); err != nil {
+		return err
+	}
+	name := man.Time.Format("20060102-150405.000000000") + ".json"
+	if err := os.WriteFile(filepath.Join(h.Dir, name), data, 0644); err != nil {
+		return err
+	}
+
+	dropped := append([]string(nil), h.Entries[h.Position+1:]...)
+	h.Entries = append(h.Entries[:h.Position+1], name)
+	for len(h.Entries) > historyLimit {
+		dropped = append(dropped, h.Entries[0])
+		h.Entries = h.Entries[1:]
+	}
+	h.Position = len(h.Entries) - 1
+	if err := h.Save(); err != nil {
+		return err
+	}
+	if err := cache.addHistory(h.Dir); err != nil {
+		log.Printf("Could not register history: %v", err)
+	}
+	h.collect(dropped, cache)
+	return nil
+}
+
+// Deletes dropped manifests, then evicts the cache: index entries and
+// stored sprites no state in any game's history refers to are dropped,
+// which covers every sprite in use since each run records the state it
+// leaves. If a history can't be read nothing is evicted.
+func (h *History) collect(dropped []string, cache *SpriteCache) {
+	if len(dropped) == 0 {
+		return
+	}
+	for _, name := range dropped {
+		os.Remove(filepath.Join(h.Dir, name))
+	}
+
+	referenced := make(map[string]bool)
+	for _, dir := range cache.histories() {
+		other := loadHistory(dir)
+		for i := range other.Entries {
+			man, err := other.manifest(i)
+			if err != nil {
+				log.Printf("Keeping stored sprites, %s is unreadable: %v", other.Entries[i], err)
+				return
+			}
+			for _, sum := range man.Files {
+				referenced[sum] = true
+			}
+		}
+	}
+
+	cache.mu.Lock()
+	for key, sum := range cache.index {
+		if !referenced[sum] {
+			delete(cache.index, key)
+		}
+	}
+	cache.mu.Unlock()
+	if err := cache.Save(); err != nil {
+		log.Printf("Could not write cache index: %v", err)
+	}
+
+	filepath.Walk(filepath.Join(cache.Dir, "objects"), func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() && filepath.Ext(path) == ".png" && !referenced[strings.TrimSuffix(info.Name(), ".png")] {
+			os.Remove(path)
+		}
+		return nil
+	})
+}
+
+// Records the sprite folder's state if it isn't the state history
+// thinks it's in, e.g. after a restore or a game update
+func (h *History) Sync(man Manifest, cache *SpriteCache) error {
+	if current, err := h.manifest(h.Position); err == nil && maps.Equal(current.Files, man.Files) {
+		return nil
+	}
+	return h.Push(man, cache)
+}
+
+// Returns the history folders of every game whose states are in the
+// object store
+func (c *SpriteCache) histories() []string {
+	var dirs []string
+	if data, err := os.ReadFile(filepath.Join(c.Dir, "histories.json")); err == nil {
+		json.Unmarshal(data, &dirs)
+	}
+	return dirs
+}
+
+// Notes that a history keeps its states in the object store, so
+// collecting another game's history leaves them alone
+func (c *SpriteCache) addHistory(dir string) error {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	dirs := c.histories()
+	if slices.Contains(dirs, dir) {
+		return nil
+	}
+	data, err := json.MarshalIndent(append(dirs, dir), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(c.Dir, "histories.json"), data, 0644)
+}
+
+// Stores a file in the object store by content and returns its hash.
+// The file is copied rather than hardlinked, since an installer may
+// rewrite game files in place.
+func (c *SpriteCache) StoreFile(path string) (string, error) {
+	sum, err := hashFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	object := c.objectPath(sum)
+	if !exists(object) {
+		if err := os.MkdirAll(filepath.Dir(object), 0755); err != nil {
+			return "", err
+		}
+		if _, err := deployFile(path, object, false); err != nil {
+			return "", err
+		}
+	}
+	return sum, nil
+}
+
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Returns the keys of every sprite under a sprite folder
+func spriteKeys(spriteDir string) ([]string, error) {
+	// Walk won't enter the folder if it's an overlay symlink
+	root, err := filepath.EvalSymlinks(spriteDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() && filepath.Ext(path) == ".png" {
+			rel, _ := filepath.Rel(root, path)
+			keys = append(keys, strings.TrimSuffix(filepath.ToSlash(rel), ".png"))
+		}
+		return nil
+	})
+	return keys, nil
+}
+
+// Returns the content hash of every sprite under a sprite folder
+func hashSprites(spriteDir string) (map[string]string, error) {
+	keys, err := spriteKeys(spriteDir)
+	if err != nil {
+		return nil, err
+	}
+	sums := make([]string, len(keys))
+	errs := make([]error, len(keys))
+	parallel(len(keys), randomizeWorkers, func(i int) {
+		sums[i], errs[i] = hashFile(filepath.Join(spriteDir, keys[i]+".png"))
+	})
+
+	hashes := make(map[string]string, len(keys))
+	for i, key := range keys {
+		hashes[key] = sums[i]
+	}
+	return hashes, errors.Join(errs...)
+}
+
+// Stores every sprite in the folder and returns the folder's manifest
+func snapshot(spriteDir string, cache *SpriteCache) (Manifest, error) {
+	keys, err := spriteKeys(spriteDir)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	sums := make([]string, len(keys))
+	errs := make([]error, len(keys))
+	parallel(len(keys), randomizeWorkers, func(i int) {
+		sums[i], errs[i] = cache.StoreFile(filepath.Join(spriteDir, keys[i]+".png"))
+	})
+	if err := errors.Join(errs...); err != nil {
+		return Manifest{}, err
+	}
+
+	man := Manifest{Time: time.Now(), Files: make(map[string]string, len(keys))}
+	for i, key := range keys {
+		man.Files[key] = sums[i]
+	}
+	man.Output, man.Randomized = overlayFor(spriteDir).State()
+	return man, nil
+}
+
+// Puts back the overlay set a state was recorded with, so stepping
+// between a run and the state before it switches folders instead of
+// rewriting the randomized set with the originals. In place states live
+// in the originals. Reports whether anything was switched.
+func restoreOverlay(spriteDir string, man Manifest) (bool, error) {
+	overlay := overlayFor(spriteDir)
+	if man.Output == "" || !overlay.Present() {
+		return false, nil
+	}
+	mode, randomized := overlay.State()
+	want := man.Output
+	if want == outputInPlace {
+		want = mode
+	}
+	if want == mode && man.Randomized == randomized {
+		return false, nil
+	}
+	if man.Randomized && !randomized && !exists(overlay.Randomized) {
+		return false, nil
+	}
+	return true, overlay.Switch(man.Randomized, want)
+}
+
+// Puts the sprite folder from its current state into a recorded one,
+// only touching the sprites that differ, and brings back that state's
+// run log. Sprites the recorded state didn't have are removed.
+func applyManifest(man, current Manifest, spriteDir string, cache *SpriteCache) error {
+	var keys []string
+	for key, sum := range man.Files {
+		if current.Files[key] != sum {
+			keys = append(keys, key)
+		}
+	}
+	var errs []error
+	for key := range current.Files {
+		if _, ok := man.Files[key]; !ok {
+			if err := os.Remove(filepath.Join(spriteDir, key+".png")); err != nil && !os.IsNotExist(err) {
+				errs = append(errs, err)
+			}
+		}
+	}
+	var mu sync.Mutex
+	parallel(len(keys), randomizeWorkers, func(i int) {
+		sum := man.Files[keys[i]]
+		object := cache.objectPath(sum)
+		err := fmt.Errorf("stored sprite %s is damaged", keys[i])
+		if actual, _ := hashFile(object); actual == sum {
+			// never hardlinked: undo must not leave the game sharing
+			// inodes with the object store
+			_, err = deployFile(object, filepath.Join(spriteDir, keys[i]+".png"), false)
+		}
+		if err != nil {
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+		}
+	})
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	if man.Run != nil {
+		return saveRunLog(runLogFile, *man.Run)
+	}
+	os.Remove(runLogFile)
+	return nil
+}
+
+// Returns the manifest the last run left, searching back from the
+// current state
+func (h *History) LastRun() (Manifest, bool) {
+	for i := h.Position; i >= 0; i-- {
+		if man, err := h.manifest(i); err == nil && man.Run != nil {
+			return man, true
+		}
+	}
+	return Manifest{}, false
+}
+
+// Moves the sprite folder back (-1) or forward (+1) through its history
+func (m model) stepHistory(step int) (tea.Model, tea.Cmd) {
+	if m.spritePath == "" {
+		m.message = "Select a game first."
+		return m, nil
+	}
+
+	cache := openSpriteCache(spriteCacheDir)
+	history := loadHistory(historyDir(m.spritePath))
+
+	// anything done to the folder outside the randomizer becomes a state
+	// of its own, so stepping away from it can be stepped back
+	current, err := snapshot(m.spritePath, cache)
+	if err == nil {
+		err = history.Sync(current, cache)
+	}
+	if err != nil {
+		log.Printf("Could not record sprite folder state: %v", err)
+		m.message = "Could not read the sprite folder."
+		return m, nil
+	}
+
+	target := history.Position + step
+	man, err := history.manifest(target)
+	if err != nil {
+		if step < 0 {
+			m.message = "Nothing to undo."
+		} else {
+			m.message = "Nothing to redo."
+		}
+		return m, nil
+	}
+	switched, err := restoreOverlay(m.spritePath, man)
+	if err == nil && switched {
+		current.Files, err = hashSprites(m.spritePath)
+	}
+	if err != nil {
+		log.Printf("Could not switch sprite folders: %v", err)
+		m.message = "Could not switch sprite folders."
+		return m, nil
+	}
+	if err := applyManifest(man, current, m.spritePath, cache); err != nil {
+		log.Printf("Could not restore sprite folder state: %v", err)
+		m.message = "Could not restore that state."
+		return m, nil
+	}
+
+	history.Position = target
+	if err := history.Save(); err != nil {
+		log.Printf("Could not write history: %v", err)
+	}
+	m.message = fmt.Sprintf("Sprites put back to %s (%d/%d).", man.Time.Format("2006-01-02 15:04"), target+1, len(history.Entries))
+	return m, nil
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+// Records the folder's current state, as a run's would be with run set
+func pushState(t *testing.T, h *History, spriteDir string, cache *SpriteCache, run *RunLog) Manifest {
+	t.Helper()
+	man, err := snapshot(spriteDir, cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	man.Run = run
+	if err := h.Push(man, cache); err != nil {
+		t.Fatal(err)
+	}
+	return man
+}
+
+func step(t *testing.T, m model, n int) model {
+	t.Helper()
+	next, _ := m.stepHistory(n)
+	return next.(model)
+}
+
+func TestHistoryUndoRedo(t *testing.T) {
+	t.Chdir(t.TempDir())
+	spriteDir, _ := filepath.Abs(filepath.Join("game", "sprite"))
+	a, b, c := filepath.Join(spriteDir, "a.png"), filepath.Join(spriteDir, "b.png"), filepath.Join(spriteDir, "c.png")
+	writeSprite(t, a, "vanilla a")
+	writeSprite(t, b, "vanilla b")
+
+	cache := openSpriteCache(spriteCacheDir)
+	h := loadHistory(historyDir(spriteDir))
+	before, err := snapshot(spriteDir, cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Sync(before, cache); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Sync(before, cache); err != nil || len(h.Entries) != 1 {
+		t.Fatalf("Sync of an unchanged folder recorded a state: %v, %d entries", err, len(h.Entries))
+	}
+
+	writeSprite(t, a, "randomized a")
+	writeSprite(t, c, "randomized c")
+	after := pushState(t, h, spriteDir, cache, &RunLog{Seed: 42})
+
+	m := step(t, model{spritePath: spriteDir}, -1)
+	if got := readSprite(t, a); got != "vanilla a" {
+		t.Errorf("undo left a.png = %q", got)
+	}
+	if exists(c) {
+		t.Error("undo kept c.png, which the earlier state didn't have")
+	}
+	if sameFile(a, cache.objectPath(before.Files["a"])) {
+		t.Error("undo hardlinked a.png to the object store")
+	}
+	if m = step(t, m, -1); m.message != "Nothing to undo." {
+		t.Errorf("second undo: %q", m.message)
+	}
+
+	m = step(t, m, 1)
+	if got := readSprite(t, a); got != "randomized a" {
+		t.Errorf("redo left a.png = %q", got)
+	}
+	if got := readSprite(t, c); got != "randomized c" {
+		t.Errorf("redo left c.png = %q", got)
+	}
+	if got := readSprite(t, b); got != "vanilla b" {
+		t.Errorf("b.png = %q", got)
+	}
+	if m = step(t, m, 1); m.message != "Nothing to redo." {
+		t.Errorf("second redo: %q", m.message)
+	}
+
+	last, ok := loadHistory(historyDir(spriteDir)).LastRun()
+	if !ok || last.Run.Seed != 42 || last.Files["c"] != after.Files["c"] {
+		t.Errorf("LastRun = %+v, %v", last, ok)
+	}
+}
+
+func TestHistoryEvictsDroppedStates(t *testing.T) {
+	t.Chdir(t.TempDir())
+	spriteDir, _ := filepath.Abs("sprite")
+	sprite := filepath.Join(spriteDir, "a.png")
+	cache := openSpriteCache(spriteCacheDir)
+	h := loadHistory(historyDir(spriteDir))
+
+	// the first and the last state are processed sprites, put in the
+	// cache by the run that leaves them
+	var states []Manifest
+	for i := 0; i < historyLimit+2; i++ {
+		content := fmt.Sprintf("state %d", i)
+		switch i {
+		case 0:
+			content = "first"
+		case historyLimit + 1:
+			content = "last"
+		}
+		if _, err := cache.Put(content, []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+		writeSprite(t, sprite, content)
+		states = append(states, pushState(t, h, spriteDir, cache, nil))
+	}
+
+	if len(h.Entries) != historyLimit {
+		t.Fatalf("%d states kept, want %d", len(h.Entries), historyLimit)
+	}
+	for i, man := range states {
+		object := cache.objectPath(man.Files["a"])
+		if want := i >= 2; exists(object) != want {
+			t.Errorf("state %d: object kept = %v, want %v", i, exists(object), want)
+		}
+	}
+	if _, ok := cache.Get("first"); ok {
+		t.Error("index entry of a dropped state wasn't evicted")
+	}
+	if _, ok := cache.Get("last"); !ok {
+		t.Error("index entry of a kept state was evicted")
+	}
+}
+
+func TestHistoryUndoSwitchesOverlay(t *testing.T) {
+	t.Chdir(t.TempDir())
+	spriteDir, _ := filepath.Abs("sprite")
+	overlay := overlayFor(spriteDir)
+	writeSprite(t, filepath.Join(spriteDir, "a.png"), "vanilla a")
+
+	cache := openSpriteCache(spriteCacheDir)
+	h := loadHistory(historyDir(spriteDir))
+	pushState(t, h, spriteDir, cache, nil)
+
+	staging := filepath.Join(t.TempDir(), "staging")
+	writeSprite(t, filepath.Join(staging, "a.png"), "randomized a")
+	if err := overlay.Install(staging, outputRename); err != nil {
+		t.Fatal(err)
+	}
+	after := pushState(t, h, spriteDir, cache, &RunLog{})
+	if after.Output != outputRename || !after.Randomized {
+		t.Fatalf("overlay recorded as %q, randomized %v", after.Output, after.Randomized)
+	}
+
+	m := step(t, model{spritePath: spriteDir}, -1)
+	if overlay.Active() {
+		t.Error("undo didn't switch the originals back in")
+	}
+	if got := readSprite(t, filepath.Join(spriteDir, "a.png")); got != "vanilla a" {
+		t.Errorf("undo left a.png = %q", got)
+	}
+	if got := readSprite(t, filepath.Join(overlay.Randomized, "a.png")); got != "randomized a" {
+		t.Errorf("undo rewrote the randomized set: %q", got)
+	}
+
+	step(t, m, 1)
+	if !overlay.Active() {
+		t.Error("redo didn't switch the randomized set back in")
+	}
+	if got := readSprite(t, filepath.Join(overlay.Vanilla, "a.png")); got != "vanilla a" {
+		t.Errorf("redo rewrote the originals: %q", got)
+	}
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+)
+
+// lines a replacement sprite can be aligned on
+const (
+	anchorFeet = "feet" // bottom of the figure stays where the original's was
+	anchorHead = "head" // top of the head stays where the original's was
+)
+
+// Character → anchor lookup table for art that should hang from the
+// head; everyone else stands on their feet. A calibration's anchor
+// overrides it.
+var CharacterAnchors = map[string]string{}
+
+func anchorFor(character string) string {
+	if a, ok := CharacterAnchors[character]; ok {
+		return a
+	}
+	return anchorFeet
+}
+
+// Fits a Mei sprite onto the original game sprite's canvas: same canvas
+// size, figure scaled to the original figure's height, centred on it
+// horizontally and aligned on the anchor vertically, then adjusted by
+// the calibration, then run through the effect chain. Without an
+// original to fit to or effects to apply the replacement is returned
+// untouched.
+func processSprite(original, replacement []byte, character string, cal Calibration, effects []string) ([]byte, error) {
+	orig, origErr := png.Decode(bytes.NewReader(original))
+	if origErr != nil && len(effects) == 0 {
+		return replacement, nil
+	}
+	repl, err := png.Decode(bytes.NewReader(replacement))
+	if err != nil {
+		return nil, err
+	}
+
+	var out *image.NRGBA
+	if origErr == nil {
+		out = fitSprite(orig, repl, cal.anchor(character), cal)
+	} else {
+		out = crop(repl, repl.Bounds())
+	}
+	out = applyEffects(out, effects)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, out); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func fitSprite(orig, repl image.Image, anchor string, cal Calibration) *image.NRGBA {
+	canvas := image.NewNRGBA(image.Rect(0, 0, orig.Bounds().Dx(), orig.Bounds().Dy()))
+
+	target := opaqueBounds(orig).Sub(orig.Bounds().Min)
+	figure := opaqueBounds(repl)
+	if target.Empty() || figure.Empty() {
+		return canvas
+	}
+
+	h := int(math.Round(float64(target.Dy()) * cal.scale()))
+	w := int(math.Round(float64(figure.Dx()) * float64(h) / float64(figure.Dy())))
+	if w < 1 || h < 1 {
+		return canvas
+	}
+	scaled := resize(crop(repl, figure), w, h)
+
+	x := target.Min.X + (target.Dx()-w)/2 + cal.OffsetX
+	y := target.Max.Y - h + cal.OffsetY
+	if anchor == anchorHead {
+		y = target.Min.Y + cal.OffsetY
+	}
+
+	draw.Draw(canvas, image.Rect(x, y, x+w, y+h), scaled, image.Point{}, draw.Over)
+	return canvas
+}
+
+// Returns the bounds of the pixels that aren't fully transparent
+func opaqueBounds(img image.Image) image.Rectangle {
+	b := img.Bounds()
+	minX, minY, maxX, maxY := b.Max.X, b.Max.Y, b.Min.X, b.Min.Y
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if _, _, _, a := img.At(x, y).RGBA(); a == 0 {
+				continue
+			}
+			if x < minX {
+				minX = x
+			}
+			if x >= maxX {
+				maxX = x + 1
+			}
+			if y < minY {
+				minY = y
+			}
+			if y >= maxY {
+				maxY = y + 1
+			}
+		}
+	}
+	if maxX <= minX || maxY <= minY {
+		return image.Rectangle{}
+	}
+	return image.Rect(minX, minY, maxX, maxY)
+}
+
+func crop(img image.Image, r image.Rectangle) *image.NRGBA {
+	out := image.NewNRGBA(image.Rect(0, 0, r.Dx(), r.Dy()))
+	draw.Draw(out, out.Bounds(), img, r.Min, draw.Src)
+	return out
+}
+
+// Resizes with premultiplied bilinear sampling, halving first while the
+// image is more than twice the target so downscales don't alias
+func resize(src *image.NRGBA, w, h int) *image.NRGBA {
+	for src.Bounds().Dx() >= 2*w && src.Bounds().Dy() >= 2*h {
+		src = halve(src)
+	}
+
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	out := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		fy := (float64(y)+0.5)*float64(sh)/float64(h) - 0.5
+		for x := 0; x < w; x++ {
+			fx := (float64(x)+0.5)*float64(sw)/float64(w) - 0.5
+			out.SetNRGBA(x, y, bilinear(src, fx, fy))
+		}
+	}
+	return out
+}
+
+func bilinear(src *image.NRGBA, fx, fy float64) color.NRGBA {
+	x0, y0 := int(math.Floor(fx)), int(math.Floor(fy))
+	tx, ty := fx-float64(x0), fy-float64(y0)
+
+	var r, g, b, a float64
+	for _, s := range [4]struct {
+		dx, dy int
+		w      float64
+	}{
+		{0, 0, (1 - tx) * (1 - ty)},
+		{1, 0, tx * (1 - ty)},
+		{0, 1, (1 - tx) * ty},
+		{1, 1, tx * ty},
+	} {
+		c := src.NRGBAAt(clamp(x0+s.dx, src.Bounds().Dx()), clamp(y0+s.dy, src.Bounds().Dy()))
+		ca := float64(c.A) * s.w
+		r += float64(c.R) * ca
+		g += float64(c.G) * ca
+		b += float64(c.B) * ca
+		a += ca
+	}
+	if a == 0 {
+		return color.NRGBA{}
+	}
+	return color.NRGBA{
+		R: uint8(math.Round(r / a)),
+		G: uint8(math.Round(g / a)),
+		B: uint8(math.Round(b / a)),
+		A: uint8(math.Round(a)),
+	}
+}
+
+// Averages 2×2 blocks, weighting colour by alpha
+func halve(src *image.NRGBA) *image.NRGBA {
+	w, h := src.Bounds().Dx()/2, src.Bounds().Dy()/2
+	out := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var r, g, b, a int
+			for _, p := range [4]image.Point{{0, 0}, {1, 0}, {0, 1}, {1, 1}} {
+				c := src.NRGBAAt(2*x+p.X, 2*y+p.Y)
+				r += int(c.R) * int(c.A)
+				g += int(c.G) * int(c.A)
+				b += int(c.B) * int(c.A)
+				a += int(c.A)
+			}
+			if a == 0 {
+				continue
+			}
+			out.SetNRGBA(x, y, color.NRGBA{
+				R: uint8(r / a),
+				G: uint8(g / a),
+				B: uint8(b / a),
+				A: uint8(a / 4),
+			})
+		}
+	}
+	return out
+}
+
+func clamp(v, n int) int {
+	if v < 0 {
+		return 0
+	}
+	if v >= n {
+		return n - 1
+	}
+	return v
+}
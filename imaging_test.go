@@ -0,0 +1,54 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+// Returns a w×h transparent image with an opaque block over r
+func figureImage(w, h int, r image.Rectangle) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(img, r, image.NewUniform(color.NRGBA{200, 100, 50, 255}), image.Point{}, draw.Src)
+	return img
+}
+
+func TestFitSprite(t *testing.T) {
+	orig := figureImage(100, 200, image.Rect(30, 50, 70, 190))
+	repl := figureImage(50, 100, image.Rect(10, 0, 40, 90))
+
+	tests := []struct {
+		name   string
+		anchor string
+		cal    Calibration
+		want   image.Rectangle // figure bounds on the output canvas
+	}{
+		{"feet", anchorFeet, Calibration{}, image.Rect(27, 50, 74, 190)},
+		{"head", anchorHead, Calibration{}, image.Rect(27, 50, 74, 190)},
+		{"half size on the feet", anchorFeet, Calibration{Scale: 0.5}, image.Rect(38, 120, 61, 190)},
+		{"half size from the head", anchorHead, Calibration{Scale: 0.5}, image.Rect(38, 50, 61, 120)},
+		{"offset", anchorFeet, Calibration{Scale: 0.5, OffsetX: 5, OffsetY: -10}, image.Rect(43, 110, 66, 180)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := fitSprite(orig, repl, tt.anchor, tt.cal)
+			if out.Bounds() != orig.Bounds() {
+				t.Errorf("canvas %v, want %v", out.Bounds(), orig.Bounds())
+			}
+			if got := opaqueBounds(out); got != tt.want {
+				t.Errorf("figure at %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFitSpriteEmptyOriginal(t *testing.T) {
+	orig := image.NewNRGBA(image.Rect(0, 0, 100, 200))
+	repl := figureImage(50, 100, image.Rect(10, 0, 40, 90))
+
+	out := fitSprite(orig, repl, anchorFeet, Calibration{})
+	if out.Bounds() != orig.Bounds() || !opaqueBounds(out).Empty() {
+		t.Errorf("fit onto an empty original drew %v on %v", opaqueBounds(out), out.Bounds())
+	}
+}
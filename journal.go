@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Journal records the sprites a run replaces in the live sprite folder,
+// keeping each one's previous file, so a run that fails can be rolled
+// back as a whole
+type Journal struct {
+	Dir string
+	mu  sync.Mutex
+	f   *os.File
+}
+
+// JournalEntry is one replaced sprite
+type JournalEntry struct {
+	Key     string `json:"key"`
+	Existed bool   `json:"existed"` // whether there was a sprite to put back
+}
+
+// journal of the run in progress
+func journalDir(spriteDir string) string { return spriteDir + "_journal" }
+
+func openJournal(dir string) (*Journal, error) {
+	if err := os.MkdirAll(filepath.Join(dir, "files"), 0755); err != nil {
+		return nil, err
+	}
+	f, err := os.Create(filepath.Join(dir, "journal.jsonl"))
+	if err != nil {
+		return nil, err
+	}
+	return &Journal{Dir: dir, f: f}, nil
+}
+
+// Keeps the sprite at dst before a run replaces it
+func (j *Journal) Record(key, dst string) error {
+	entry := JournalEntry{Key: key, Existed: exists(dst)}
+	if entry.Existed {
+		prev := filepath.Join(j.Dir, "files", key+".png")
+		if err := os.MkdirAll(filepath.Dir(prev), 0755); err != nil {
+			return err
+		}
+		// dst is only ever replaced by rename, so a hardlink keeps the old file
+		if os.Link(dst, prev) != nil {
+			if err := copyFile(dst, prev); err != nil {
+				return err
+			}
+		}
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	_, err = j.f.Write(append(line, '\n'))
+	return err
+}
+
+func (j *Journal) Close() error {
+	return j.f.Close()
+}
+
+func readJournal(dir string) ([]JournalEntry, error) {
+	f, err := os.Open(filepath.Join(dir, "journal.jsonl"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []JournalEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e JournalEntry
+		// a line cut off by a crash is the last one and wasn't written yet
+		if json.Unmarshal(scanner.Bytes(), &e) == nil {
+			entries = append(entries, e)
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// Puts back every sprite a journal recorded
+func rollbackJournal(dir, spriteDir string) error {
+	entries, err := readJournal(dir)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, e := range entries {
+		dst := filepath.Join(spriteDir, e.Key+".png")
+		if e.Existed {
+			prev := filepath.Join(dir, "files", e.Key+".png")
+			if sameFile(prev, dst) {
+				// never replaced, the write failed
+				continue
+			}
+			_, err = deployFile(prev, dst, true)
+		} else {
+			err = os.Remove(dst)
+		}
+		if err != nil && !os.IsNotExist(err) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func sameFile(a, b string) bool {
+	ia, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+	ib, err := os.Stat(b)
+	return err == nil && os.SameFile(ia, ib)
+}
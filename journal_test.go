@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeSprite(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readSprite(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestRollbackAfterFailedWrite(t *testing.T) {
+	root := t.TempDir()
+	spriteDir := filepath.Join(root, "sprite")
+	writeSprite(t, filepath.Join(spriteDir, "a.png"), "vanilla a")
+	writeSprite(t, filepath.Join(spriteDir, "b.png"), "vanilla b")
+	replacement := filepath.Join(root, "new.png")
+	writeSprite(t, replacement, "randomized")
+
+	journal, err := openJournal(journalDir(spriteDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r := &randomizeRun{outDir: spriteDir, journal: journal, abort: cancel}
+
+	if _, err := r.write("a", replacement, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.write("c", replacement, false); err != nil {
+		t.Fatal(err)
+	}
+	// the run stops at a sprite it can't write
+	if _, err := r.write("b", filepath.Join(root, "missing.png"), false); err == nil {
+		t.Fatal("write of a missing file succeeded")
+	}
+	if ctx.Err() == nil || r.writeErr == nil {
+		t.Fatal("failed write didn't abort the run")
+	}
+	journal.Close()
+
+	if err := rollbackJournal(journalDir(spriteDir), spriteDir); err != nil {
+		t.Fatal(err)
+	}
+	if got := readSprite(t, filepath.Join(spriteDir, "a.png")); got != "vanilla a" {
+		t.Errorf("a.png = %q after rollback", got)
+	}
+	if got := readSprite(t, filepath.Join(spriteDir, "b.png")); got != "vanilla b" {
+		t.Errorf("b.png = %q after rollback", got)
+	}
+	for _, name := range []string{"c.png", "b.png.tmp"} {
+		if exists(filepath.Join(spriteDir, name)) {
+			t.Errorf("%s left behind by rollback", name)
+		}
+	}
+}
+
+func TestReadJournalSkipsCutOffLine(t *testing.T) {
+	dir := t.TempDir()
+	writeSprite(t, filepath.Join(dir, "journal.jsonl"), "{\"key\":\"a\",\"existed\":true}\n{\"key\":\"b\",\"exi")
+
+	entries, err := readJournal(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Key != "a" || !entries[0].Existed {
+		t.Errorf("readJournal = %+v", entries)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/base64"
+	"html/template"
+	"os"
+	"path/filepath"
+)
+
+// LineupCard is one character's tile on the contact sheet
+type LineupCard struct {
+	Character string
+	Outfit    string
+	Image     template.URL // data: URI so the page can be shared on its own
+}
+
+var lineupTemplate = template.Must(template.New("lineup").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Higurashi Randomizer Lineup</title>
+<style>
+body { background: #1b1b22; color: #eee; font-family: sans-serif; }
+.grid { display: grid; grid-template-columns: repeat(auto-fill, minmax(180px, 1fr)); gap: 12px; }
+.card { background: #2a2a35; border-radius: 6px; padding: 8px; text-align: center; }
+.card img { width: 100%; height: 240px; object-fit: contain; }
+.card .missing { height: 240px; display: flex; align-items: center; justify-content: center; color: #888; }
+.name { font-weight: bold; }
+.outfit { color: #aaa; font-size: 0.9em; }
+</style>
+</head>
+<body>
+<h1>Lineup</h1>
+<div class="grid">
+{{range .}}<div class="card">
+{{if .Image}}<img src="{{.Image}}" alt="{{.Character}}">{{else}}<div class="missing">no preview</div>{{end}}
+<div class="name">{{.Character}}</div>
+<div class="outfit">{{.Outfit}}</div>
+</div>
+{{end}}</div>
+</body>
+</html>
+`))
+
+// Returns the Mei folder, variant and label shown for a character's
+// current selection; random selections show their first candidate
+func (m model) lineupOutfit(character string) (string, string, string) {
+	source := swapSource(m.swaps, character)
+	folder := meiFolder(source)
+	selection := m.selections[source]
+	label := selection
+
+	variant := selectionVariant(selection)
+	if variant == "" {
+		if o, ok := bestMatch(source, m.filters); ok {
+			variant = o.SpriteSet
+		} else {
+			variant = defaultVariant(folder)
+		}
+	}
+	if source != character {
+		label += " as " + source
+	}
+	return folder, variant, label
+}
+
+// Writes an HTML contact sheet of every character's selected outfit
+func (m model) exportLineup(path string) error {
+	var cards []LineupCard
+	for _, c := range spriteChoices {
+		folder, variant, label := m.lineupOutfit(c)
+		card := LineupCard{Character: c, Outfit: label}
+
+		if m.selections[c] != keepOriginal || swapSource(m.swaps, c) != c {
+			data, err := readMeiSprite(folder, variant, normal_open)
+			if err == nil {
+				card.Image = template.URL("data:image/png;base64," + base64.StdEncoding.EncodeToString(data))
+			}
+		}
+		cards = append(cards, card)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return lineupTemplate.Execute(f, cards)
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// mouth animation frames 07th-Mod cycles through while a character talks
+const (
+	mouthClosed = 0
+	mouthHalf   = 1
+	mouthOpen   = 2
+)
+
+// Splits a key like me1a_warai_a1_2 into its pose (me1a_warai_a1_) and
+// mouth frame (2). Only poses with more than one mapped frame count as
+// lip synced; a lone _0/_1/_2 key keeps its hand-written mapping.
+func lipSyncFrame(key string) (string, int, bool) {
+	i := strings.LastIndex(key, "_")
+	if i == -1 || i != len(key)-2 {
+		return "", 0, false
+	}
+	frame := int(key[i+1] - '0')
+	if frame < mouthClosed || frame > mouthOpen {
+		return "", 0, false
+	}
+	pose := key[:i+1]
+	if len(mouthFrames(pose)) < 2 {
+		return "", 0, false
+	}
+	return pose, frame, true
+}
+
+// Strips the mouth state from a Mei expression: smile_blush_open → smile_blush
+func expressionBase(expression string) string {
+	for _, suffix := range []string{"_open", "_close", "_half"} {
+		if strings.HasSuffix(expression, suffix) {
+			return strings.TrimSuffix(expression, suffix)
+		}
+	}
+	return expression
+}
+
+// Picks the Mei file for a mouth frame of the given expression
+func withMouth(base string, frame int) string {
+	switch frame {
+	case mouthClosed:
+		return base + "_close"
+	case mouthHalf:
+		return base + "_half"
+	}
+	return base + "_open"
+}
+
+// Returns the expression shared by all frames of a pose: whichever the
+// frames were mapped to most, preferring the lowest frame on a tie
+func poseBase(pose string) string {
+	counts := make(map[string]int)
+	best := ""
+	for _, key := range mouthFrames(pose) {
+		base := expressionBase(RawGameSprites[key][0])
+		counts[base]++
+		if best == "" || counts[base] > counts[best] {
+			best = base
+		}
+	}
+	return best
+}
+
+// Returns the Mei expression for a key, keeping the mouth frames of a
+// talking pose on the same face
+func MappedExpression(key string) string {
+	pose, frame, ok := lipSyncFrame(key)
+	if !ok {
+		return RawGameSprites[key][0]
+	}
+	return withMouth(poseBase(pose), frame)
+}
+
+// Reads a Mei sprite, falling back through the taxonomy to faces the
+// variant has when the pack doesn't draw the one asked for
+func readMeiSprite(folder, variant, expression string) ([]byte, error) {
+	data, _, err := resolveMeiSprite(folder, variant, expression)
+	return data, err
+}
+
+// Like readMeiSprite, also returning the face that was actually read
+func resolveMeiSprite(folder, variant, expression string) ([]byte, string, error) {
+	var err error
+	for _, candidate := range LoadTaxonomy(folder).Fallbacks(expression) {
+		var data []byte
+		if data, err = readMeiFace(folder, variant, candidate); err == nil {
+			return data, candidate, nil
+		}
+	}
+	return nil, "", err
+}
+
+// Reads one Mei face, synthesizing the half-open mouth frame from the
+// closed and open ones when the pack doesn't ship a *_half file
+func readMeiFace(folder, variant, expression string) ([]byte, error) {
+	dir := filepath.Join("sprites", "mei", folder, variant)
+	src := filepath.Join(dir, expression+".png")
+
+	data, err := os.ReadFile(src)
+	if err == nil || !strings.HasSuffix(expression, "_half") {
+		return data, err
+	}
+
+	base := expressionBase(expression)
+	closed, cerr := os.ReadFile(filepath.Join(dir, base+"_close.png"))
+	open, oerr := os.ReadFile(filepath.Join(dir, base+"_open.png"))
+	switch {
+	case cerr != nil && oerr != nil:
+		return nil, err
+	case oerr != nil:
+		return closed, nil
+	case cerr != nil:
+		return open, nil
+	}
+	return synthesizeHalfFrame(closed, open)
+}
+
+// Blends the closed and open mouth frames into an in-between one
+func synthesizeHalfFrame(closed, open []byte) ([]byte, error) {
+	a, err := png.Decode(bytes.NewReader(closed))
+	if err != nil {
+		return open, nil
+	}
+	b, err := png.Decode(bytes.NewReader(open))
+	if err != nil || a.Bounds() != b.Bounds() {
+		return open, nil
+	}
+
+	bounds := a.Bounds()
+	half := image.NewNRGBA(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			ca := color.NRGBAModel.Convert(a.At(x, y)).(color.NRGBA)
+			cb := color.NRGBAModel.Convert(b.At(x, y)).(color.NRGBA)
+			half.SetNRGBA(x, y, color.NRGBA{
+				R: uint8((uint16(ca.R) + uint16(cb.R)) / 2),
+				G: uint8((uint16(ca.G) + uint16(cb.G)) / 2),
+				B: uint8((uint16(ca.B) + uint16(cb.B)) / 2),
+				A: uint8((uint16(ca.A) + uint16(cb.A)) / 2),
+			})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, half); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import "testing"
+
+func TestLipSyncFrame(t *testing.T) {
+	tests := []struct {
+		key   string
+		pose  string
+		frame int
+		ok    bool
+	}{
+		{"me3_huteki_a1_0", "me3_huteki_a1_", mouthClosed, true},
+		{"me3_huteki_a1_1", "me3_huteki_a1_", mouthHalf, true},
+		{"me3_huteki_a1_2", "me3_huteki_a1_", mouthOpen, true},
+		{"me3_warai_a1_2", "me3_warai_a1_", mouthOpen, true},
+		// a lone frame keeps its own mapping
+		{"me3_majime_a1_0", "", 0, false},
+		{"me3_huteki_a1_5", "", 0, false},
+		{"me3_huteki_a1_10", "", 0, false},
+		{"me3", "", 0, false},
+	}
+	for _, tt := range tests {
+		pose, frame, ok := lipSyncFrame(tt.key)
+		if pose != tt.pose || frame != tt.frame || ok != tt.ok {
+			t.Errorf("lipSyncFrame(%q) = %q, %d, %v, want %q, %d, %v", tt.key, pose, frame, ok, tt.pose, tt.frame, tt.ok)
+		}
+	}
+}
+
+func TestMappedExpression(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"me3_huteki_a1_0", "futeki_close"},
+		{"me3_huteki_a1_1", "futeki_half"},
+		{"me3_huteki_a1_2", "futeki_open"},
+		// every frame of a pose shows the same face
+		{"me3_warai_a1_0", "smile_close"},
+		{"me3_warai_a1_2", "smile_open"},
+		{"me3_majime_a1_0", "sinken_open"},
+	}
+	for _, tt := range tests {
+		if got := MappedExpression(tt.key); got != tt.want {
+			t.Errorf("MappedExpression(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestExpressionBase(t *testing.T) {
+	for expression, want := range map[string]string{
+		"smile_blush_open":  "smile_blush",
+		"smile_close":       "smile",
+		"futeki_half":       "futeki",
+		"normal":            "normal",
+		"komaru_open_extra": "komaru_open_extra",
+	} {
+		if got := expressionBase(expression); got != want {
+			t.Errorf("expressionBase(%q) = %q, want %q", expression, got, want)
+		}
+	}
+}
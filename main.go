@@ -1,23 +1,33 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
-	"math/rand"
+	"maps"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/sqweek/dialog"
 )
 var SelectedVariants map[string]string
 
 type Config struct {
-	GamePath   string            `json:"game_path"`
-	SpritePath string            `json:"sprite_path"`
-	Selections map[string]string `json:"selections"`
+	GamePath     string                 `json:"game_path"`
+	SpritePath   string                 `json:"sprite_path"`
+	Selections   map[string]string      `json:"selections"`
+	Expressions  map[string]string      `json:"expressions,omitempty"`
+	Swaps        map[string]string      `json:"swaps,omitempty"`
+	Filters      map[string]bool        `json:"filters,omitempty"`
+	Calibrations map[string]Calibration `json:"calibrations,omitempty"`
+	Effects      map[string][]string    `json:"effects,omitempty"`
+	Preview      bool                   `json:"preview,omitempty"`
+	Output       string                 `json:"output,omitempty"`
 }
 func extractVariant(selection string) string {
     if selection == "" || strings.ToLower(selection) == "best match" {
@@ -26,18 +36,18 @@ func extractVariant(selection string) string {
     return selection
 }
 func getVariantForKey(key string, selectedVariants map[string]string) string {
-    folder := GetFolder(key)
-    log.Printf("[DEBUG] Key: %s, Folder: %s", key, folder)
+    character := GetCharacter(key)
+    log.Printf("[DEBUG] Key: %s, Character: %s", key, character)
 
-    sel, ok := selectedVariants[folder]
+    sel, ok := selectedVariants[character]
     if !ok {
-        log.Printf("[DEBUG] No selection found for folder '%s', falling back to default variant", folder)
+        log.Printf("[DEBUG] No selection found for character '%s', falling back to default variant", character)
         return RawGameSprites[key][1]
     }
 
-    log.Printf("[DEBUG] Selection for folder '%s': %s", folder, sel)
+    log.Printf("[DEBUG] Selection for character '%s': %s", character, sel)
 
-    if sel == "" || strings.ToLower(sel) == "best match" {
+    if sel == "" || strings.ToLower(sel) == "best match" || sel == keepOriginal {
         log.Printf("[DEBUG] Selection is empty or Best Match, using default variant: %s", RawGameSprites[key][1])
         return RawGameSprites[key][1]
     }
@@ -53,15 +63,20 @@ func getVariantForKey(key string, selectedVariants map[string]string) string {
 }
 
 
-func loadConfig() Config {
+func readConfig() (Config, error) {
+	var cfg Config
 	file, err := os.Open("config.json")
 	if err != nil {
-		return Config{Selections: make(map[string]string)}
+		return cfg, err
 	}
 	defer file.Close()
+	err = json.NewDecoder(file).Decode(&cfg)
+	return cfg, err
+}
 
-	var cfg Config
-	if err := json.NewDecoder(file).Decode(&cfg); err != nil {
+func loadConfig() Config {
+	cfg, err := readConfig()
+	if err != nil {
 		return Config{Selections: make(map[string]string)}
 	}
 	SelectedVariants = make(map[string]string)
@@ -93,6 +108,16 @@ const (
 	characterMenu
 	meiVariantMenu
 	checkSelectionsMenu
+	expressionMenu
+	swapMenu
+	castSwapMenu
+	themeMenu
+	themeFallbackMenu
+	filterMenu
+	calibrationMenu
+	effectMenu
+	progressMenu
+	statusMenu
 )
 
 var spriteChoices = []string{
@@ -103,8 +128,31 @@ var spriteChoices = []string{
 	"mo", "mura", "tamura", "une",
 }
 
+var mainMenuItems = []string{
+	"Select Game",
+	"Select Sprites",
+	"Check Selections",
+	"Install Status",
+	"Randomize",
+	"Restore Original Sprites",
+	"Undo Last Randomize",
+	"Redo",
+	"Output Mode",
+	"Toggle Overlay",
+	"Scan Game Scripts",
+	"Character Swaps",
+	"Costume Themes",
+	"Content Filters",
+	"Image Effects",
+	"Export Lineup",
+	"Comparison Report",
+	"Exit",
+}
+
 const itemsPerPage = 5
 
+var missingStyle = lipgloss.NewStyle().Faint(true)
+
 type model struct {
 	currentMenu       menu
 	cursor            int
@@ -113,11 +161,29 @@ type model struct {
 
 	filePath   string
 	spritePath string
+	chapter    string
+	counts     map[string]int // character → sprites in the selected chapter
 	message    string
 	quitting   bool
 	meiOptions []string
 
-	selections map[string]string // new: character → selected option
+	selections      map[string]string      // new: character → selected option
+	expressions     map[string]string      // character → expression mode
+	swaps           map[string]string      // character → character whose sprites are drawn
+	options         []string               // entries of the current list menu
+	theme           string                 // costume theme waiting on a fallback choice
+	filters         map[string]bool        // enabled content filters
+	calibrations    map[string]Calibration // character or character/variant → calibration
+	calibrateOutfit bool                   // editing the selected outfit's override
+	effects         map[string][]string    // character, or "" for everyone → effect chain
+	effectTarget    string                 // whose effects the effect menu edits
+	preview         bool                   // show the outfit preview pane
+	output          string                 // output mode of the next randomization
+	progress        randomizeProgressMsg   // latest progress of a background run
+	cancelRun       context.CancelFunc     // cancels the background run, nil when none
+	runUpdates      chan tea.Msg           // progress and completion of the background run
+	quitAfterRun    bool                   // quit once the cancelled run has rolled back
+	status          InstallStatus          // shown by the install status screen
 }
 
 func cursor(cur, i int) string {
@@ -127,48 +193,228 @@ func cursor(cur, i int) string {
 	return " "
 }
 
-func loadMeiOptions(charKey string) []string {
+// selection that leaves a character's game sprites untouched
+const keepOriginal = "Keep Original"
+
+func loadMeiOptions(charKey string, filters map[string]bool) []string {
 	data, ok := Characters[charKey]
 	if !ok {
 		// fallback
-		return []string{"Best Match", "Random Outfits", "Random Outfits & Expressions"}
+		return []string{"Best Match", "Random Outfits", "Random Outfits & Expressions", keepOriginal}
 	}
 
 	opts := []string{
 		"Best Match",
 		"Random Outfits",
 		"Random Outfits & Expressions",
+		keepOriginal,
 	}
 
 	for _, o := range data.OutfitsMei {
-		opts = append(opts, o.Name)
+		if o.Allowed(filters) {
+			opts = append(opts, o.Name)
+		}
+	}
+	// variant folders the catalogue doesn't name yet
+	for _, o := range installedOutfits(charKey) {
+		if strings.HasPrefix(o.Name, "Unknown ") {
+			opts = append(opts, o.Name)
+		}
 	}
 	return opts
 }
 
 func initialModel() model {
 	cfg := loadConfig()
+	PackVariants = ScanSpritePack(filepath.Join("sprites", "mei"))
+	return newModel(cfg)
+}
 
+// Returns the main menu model for a config, filling in its defaults
+func newModel(cfg Config) model {
 	if cfg.Selections == nil {
 		cfg.Selections = make(map[string]string)
 	}
+	if cfg.Expressions == nil {
+		cfg.Expressions = make(map[string]string)
+	}
+	if cfg.Swaps == nil {
+		cfg.Swaps = make(map[string]string)
+	}
+	if cfg.Filters == nil {
+		cfg.Filters = make(map[string]bool)
+	}
+	if cfg.Calibrations == nil {
+		cfg.Calibrations = make(map[string]Calibration)
+	}
+	if cfg.Effects == nil {
+		cfg.Effects = make(map[string][]string)
+	}
 	for _, c := range spriteChoices {
 		if _, ok := cfg.Selections[c]; !ok {
 			cfg.Selections[c] = "Best Match"
 		}
 	}
 
+	if cfg.Output == "" {
+		cfg.Output = outputInPlace
+	}
+
+	chapter := ChapterForGame(cfg.GamePath)
 	return model{
-		currentMenu: mainMenu,
-		filePath:    cfg.GamePath,
-		spritePath:  cfg.SpritePath,
-		selections:  cfg.Selections,
+		currentMenu:  mainMenu,
+		filePath:     cfg.GamePath,
+		spritePath:   cfg.SpritePath,
+		chapter:      chapter,
+		counts:       chapterCounts(chapter),
+		selections:   cfg.Selections,
+		expressions:  cfg.Expressions,
+		swaps:        cfg.Swaps,
+		filters:      cfg.Filters,
+		calibrations: cfg.Calibrations,
+		effects:      cfg.Effects,
+		preview:      cfg.Preview,
+		output:       cfg.Output,
+	}
+}
+
+func (m model) config() Config {
+	return Config{
+		GamePath:     m.filePath,
+		SpritePath:   m.spritePath,
+		Selections:   m.selections,
+		Expressions:  m.expressions,
+		Swaps:        m.swaps,
+		Filters:      m.filters,
+		Calibrations: m.calibrations,
+		Effects:      m.effects,
+		Preview:      m.preview,
+		Output:       m.output,
 	}
 }
 
+// option in a character's effect menu that drops their own chain
+const globalEffects = "Use Global Effects"
+
+// Labels the effects with their on/off state for the effect menu
+func (m model) effectOptions() []string {
+	var opts []string
+	chain, own := m.effects[m.effectTarget]
+	if m.effectTarget != "" {
+		chain = effectsFor(m.effects, m.effectTarget)
+		if own {
+			opts = append(opts, "[ ] "+globalEffects)
+		} else {
+			opts = append(opts, "[x] "+globalEffects)
+		}
+	}
+
+	on := make(map[string]bool)
+	for _, name := range chain {
+		on[name] = true
+	}
+	for _, name := range effectChoices {
+		if on[name] {
+			opts = append(opts, "[x] "+name)
+		} else {
+			opts = append(opts, "[ ] "+name)
+		}
+	}
+	return opts
+}
+
+// Returns the calibration key the calibration menu is editing
+func (m model) calibrationTarget() string {
+	if m.calibrateOutfit {
+		if v := selectionVariant(m.selections[m.selectedCharacter]); v != "" {
+			return calibrationKey(m.selectedCharacter, v)
+		}
+	}
+	return m.selectedCharacter
+}
+
+// Returns the calibration being edited; a new outfit override starts
+// from the character's own
+func (m model) currentCalibration() Calibration {
+	if c, ok := m.calibrations[m.calibrationTarget()]; ok {
+		return c
+	}
+	return m.calibrations[m.selectedCharacter]
+}
+
+// Labels the content filters with their on/off state for the filter menu
+func (m model) filterOptions() []string {
+	var opts []string
+	for _, f := range contentFilters {
+		if m.filters[f] {
+			opts = append(opts, "[x] "+f)
+		} else {
+			opts = append(opts, "[ ] "+f)
+		}
+	}
+	return opts
+}
+
+func chapterCounts(chapter string) map[string]int {
+	counts := make(map[string]int)
+	for _, c := range spriteChoices {
+		counts[c] = CountSpritesInChapter(c, chapter)
+	}
+	return counts
+}
+
 
 func (m model) Init() tea.Cmd { return nil }
 
+// Moves the cursor and page of a list menu over m.options
+func (m *model) pageMove(key string) {
+	start := m.page * itemsPerPage
+	visible := len(m.options) - start
+	if visible > itemsPerPage {
+		visible = itemsPerPage
+	}
+
+	switch key {
+	case "up", "k":
+		m.move(visible, true)
+	case "down", "j":
+		m.move(visible, false)
+	case "left", "h":
+		if m.page > 0 {
+			m.page--
+			m.cursor = 0
+		}
+	case "right", "l":
+		if (m.page+1)*itemsPerPage < len(m.options) {
+			m.page++
+			m.cursor = 0
+		}
+	}
+}
+
+// Returns the list menu entry under the cursor
+func (m model) pageSelection() string {
+	idx := m.page*itemsPerPage + m.cursor
+	if idx < 0 || idx >= len(m.options) {
+		return ""
+	}
+	return m.options[idx]
+}
+
+func (m model) pageView(title string) string {
+	start := m.page * itemsPerPage
+	end := start + itemsPerPage
+	if end > len(m.options) {
+		end = len(m.options)
+	}
+
+	s := fmt.Sprintf("%s Page %d\n\n", title, m.page+1)
+	for i, name := range m.options[start:end] {
+		s += fmt.Sprintf("%s %s\n", cursor(m.cursor, i), name)
+	}
+	return s + "\nUse ↑↓ ←→ Enter, q to return.\n"
+}
+
 func (m *model) move(limit int, up bool) {
 	if up && m.cursor > 0 {
 		m.cursor--
@@ -180,29 +426,66 @@ func (m *model) move(limit int, up bool) {
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case randomizeProgressMsg:
+		m.progress = msg
+		return m, waitForRun(m.runUpdates)
+
+	case randomizeDoneMsg:
+		m.cancelRun, m.runUpdates = nil, nil
+		m.currentMenu = mainMenu
+		m.message = msg.message
+		if m.quitAfterRun {
+			m.quitting = true
+			return m, tea.Quit
+		}
+		return m, nil
+
 	case tea.KeyMsg:
 		key := msg.String()
 
 		if key == "ctrl+c" {
+			if m.cancelRun != nil {
+				// let the run roll back before quitting
+				m.cancelRun()
+				m.quitAfterRun = true
+				m.message = "Cancelling..."
+				return m, nil
+			}
 			m.quitting = true
 			return m, tea.Quit
 		}
 
 		switch m.currentMenu {
+		case progressMenu:
+			if key == "esc" && m.cancelRun != nil {
+				m.cancelRun()
+				m.message = "Cancelling..."
+			}
+
+		case statusMenu:
+			switch key {
+			case "q", "esc":
+				m.currentMenu = mainMenu
+			case "r":
+				if m.status.State == installUpdated {
+					return m.reapplyAfterUpdate()
+				}
+			}
+
 		case mainMenu:
 			switch key {
 			case "q":
 				m.quitting = true
 				return m, tea.Quit
 			case "up", "k":
-				m.move(6, true)
+				m.move(len(mainMenuItems), true)
 			case "down", "j":
-				m.move(6, false)
+				m.move(len(mainMenuItems), false)
 			case "enter", " ":
-				switch m.cursor {
-				case 0:
+				switch mainMenuItems[m.cursor] {
+				case "Select Game":
 					path, err := dialog.File().
-						Title("Select Higurashi Episode (Ep01–Ep03)").
+						Title("Select Higurashi Episode (Ep01–Ep08)").
 						Filter("Higurashi Episodes", "exe").
 						Load()
 					if err != nil {
@@ -210,12 +493,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						return m, nil
 					}
 					base := filepath.Base(path)
-					allowed := map[string]bool{
-						"HigurashiEp01.exe": true,
-						"HigurashiEp02.exe": true,
-						"HigurashiEp03.exe": true,
-					}
-					if !allowed[base] {
+					if _, ok := GameChapters[base]; !ok {
 						m.message = fmt.Sprintf("Invalid file selected: %s", base)
 						return m, nil
 					}
@@ -223,25 +501,91 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					dir := filepath.Dir(path)
 					dataFolder := filepath.Join(dir, base[:len(base)-4]+"_Data")
 					m.spritePath = filepath.Join(dataFolder, "StreamingAssets", "CGAlt", "sprite")
-					saveConfig(Config{
-						GamePath:   m.filePath,
-						SpritePath: m.spritePath,
-						Selections: m.selections,
-					})
+					m.chapter = ChapterForGame(path)
+					m.counts = chapterCounts(m.chapter)
+					saveConfig(m.config())
 
 					m.message = "Game selected."
-				case 1:
+				case "Select Sprites":
 					m.currentMenu = spriteMenu
 					m.cursor = 0
 					m.page = 0
-				case 2:
+				case "Check Selections":
 					m.currentMenu = checkSelectionsMenu
 					m.cursor = 0
-				case 3:
-					return m.randomizeSprites()
-				case 4:
+				case "Randomize":
+					return m.randomizeSprites(time.Now().UnixNano())
+				case "Restore Original Sprites":
     				return m.restoreOriginalSprites()
-				case 5:
+				case "Install Status":
+					if m.spritePath == "" {
+						m.message = "Select a game first."
+						break
+					}
+					status, err := detectInstall(m.spritePath)
+					if err != nil {
+						log.Printf("Could not read sprite folder: %v", err)
+						m.message = "Could not read the sprite folder."
+						break
+					}
+					m.status = status
+					m.currentMenu = statusMenu
+				case "Undo Last Randomize":
+					return m.stepHistory(-1)
+				case "Redo":
+					return m.stepHistory(1)
+				case "Output Mode":
+					for i, mode := range outputModes {
+						if mode == m.output {
+							m.output = outputModes[(i+1)%len(outputModes)]
+							break
+						}
+					}
+					saveConfig(m.config())
+					m.message = "Output mode: " + m.output
+				case "Toggle Overlay":
+					return m.toggleOverlay()
+				case "Scan Game Scripts":
+					return m.scanGameScripts()
+				case "Character Swaps":
+					m.options = castSwapOptions()
+					m.currentMenu = castSwapMenu
+					m.cursor = 0
+					m.page = 0
+				case "Content Filters":
+					m.options = m.filterOptions()
+					m.currentMenu = filterMenu
+					m.cursor = 0
+					m.page = 0
+				case "Image Effects":
+					m.effectTarget = ""
+					m.options = m.effectOptions()
+					m.currentMenu = effectMenu
+					m.cursor = 0
+					m.page = 0
+				case "Export Lineup":
+					if err := m.exportLineup("lineup.html"); err != nil {
+						log.Printf("Could not write lineup: %v", err)
+						m.message = "Could not export lineup."
+					} else {
+						m.message = "Lineup exported to lineup.html."
+					}
+				case "Comparison Report":
+					run, err := loadRunLog(runLogFile)
+					if err != nil {
+						m.message = "Randomize first to compare."
+					} else if err := exportComparison(run, "comparison.html"); err != nil {
+						log.Printf("Could not write comparison: %v", err)
+						m.message = "Could not export comparison report."
+					} else {
+						m.message = "Comparison exported to comparison.html."
+					}
+				case "Costume Themes":
+					m.options = CostumeThemes()
+					m.currentMenu = themeMenu
+					m.cursor = 0
+					m.page = 0
+				case "Exit":
 					m.quitting = true
 					return m, tea.Quit
 				}
@@ -279,19 +623,228 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "q":
 				m.currentMenu = spriteMenu
 			case "up", "k":
-				m.move(2, true)
+				m.move(6, true)
 			case "down", "j":
-				m.move(2, false)
+				m.move(6, false)
 			case "enter", " ":
-				if m.cursor == 0 {
-					m.meiOptions = loadMeiOptions(m.selectedCharacter)
+				switch m.cursor {
+				case 0:
+					m.meiOptions = loadMeiOptions(m.selectedCharacter, m.filters)
 					m.currentMenu = meiVariantMenu
 					m.cursor = 0
 					m.page = 0
-				} else {
+				case 1:
 					m.message = fmt.Sprintf("Selected Ace Attorney for %s", m.selectedCharacter)
 					m.currentMenu = spriteMenu
+				case 2:
+					m.currentMenu = expressionMenu
+					m.cursor = 0
+				case 3:
+					m.options = swapOptions(m.selectedCharacter)
+					m.currentMenu = swapMenu
+					m.cursor = 0
+					m.page = 0
+				case 4:
+					m.calibrateOutfit = false
+					m.currentMenu = calibrationMenu
+				case 5:
+					m.effectTarget = m.selectedCharacter
+					m.options = m.effectOptions()
+					m.currentMenu = effectMenu
+					m.cursor = 0
+					m.page = 0
+				}
+			}
+
+		case effectMenu:
+			switch key {
+			case "q":
+				if m.effectTarget == "" {
+					m.currentMenu = mainMenu
+				} else {
+					m.currentMenu = characterMenu
+				}
+				m.cursor = 0
+				m.page = 0
+			case "enter", " ":
+				option := m.pageSelection()
+				name := option[len("[ ] "):]
+				if name == globalEffects {
+					if _, own := m.effects[m.effectTarget]; own {
+						delete(m.effects, m.effectTarget)
+					} else {
+						m.effects[m.effectTarget] = effectsFor(m.effects, m.effectTarget)
+					}
+				} else {
+					m.effects[m.effectTarget] = toggleEffect(effectsFor(m.effects, m.effectTarget), name)
+				}
+				saveConfig(m.config())
+				m.options = m.effectOptions()
+			default:
+				m.pageMove(key)
+			}
+
+		case calibrationMenu:
+			target := m.calibrationTarget()
+			cal := m.currentCalibration()
+
+			switch key {
+			case "q":
+				m.currentMenu = characterMenu
+				m.cursor = 0
+				return m, nil
+			case "o":
+				m.calibrateOutfit = !m.calibrateOutfit
+				return m, nil
+			case "r":
+				delete(m.calibrations, target)
+				saveConfig(m.config())
+				return m, nil
+			case "+", "=":
+				cal.Scale = cal.scale() + scaleStep
+			case "-":
+				if cal.scale() > scaleStep {
+					cal.Scale = cal.scale() - scaleStep
+				}
+			case "left", "h":
+				cal.OffsetX -= offsetStep
+			case "right", "l":
+				cal.OffsetX += offsetStep
+			case "up", "k":
+				cal.OffsetY -= offsetStep
+			case "down", "j":
+				cal.OffsetY += offsetStep
+			case "a":
+				if cal.anchor(m.selectedCharacter) == anchorHead {
+					cal.Anchor = anchorFeet
+				} else {
+					cal.Anchor = anchorHead
+				}
+			default:
+				return m, nil
+			}
+			m.calibrations[target] = cal
+			saveConfig(m.config())
+
+		case swapMenu:
+			switch key {
+			case "q":
+				m.currentMenu = characterMenu
+				m.cursor = 0
+				m.page = 0
+			case "enter", " ":
+				source := m.pageSelection()
+				if source == ownSprites {
+					delete(m.swaps, m.selectedCharacter)
+				} else {
+					m.swaps[m.selectedCharacter] = source
+				}
+				saveConfig(m.config())
+				m.message = fmt.Sprintf("Selected %s → Use Sprites Of → %s", m.selectedCharacter, source)
+				m.currentMenu = spriteMenu
+				m.cursor = 0
+				m.page = 0
+			default:
+				m.pageMove(key)
+			}
+
+		case themeMenu:
+			switch key {
+			case "q":
+				m.currentMenu = mainMenu
+				m.cursor = 0
+				m.page = 0
+			case "enter", " ":
+				m.theme = m.pageSelection()
+				if m.theme == "" {
+					break
 				}
+				m.options = themeFallbacks
+				m.currentMenu = themeFallbackMenu
+				m.cursor = 0
+				m.page = 0
+			default:
+				m.pageMove(key)
+			}
+
+		case themeFallbackMenu:
+			switch key {
+			case "q":
+				m.options = CostumeThemes()
+				m.currentMenu = themeMenu
+				m.cursor = 0
+				m.page = 0
+			case "enter", " ":
+				fallback := m.pageSelection()
+				n := applyTheme(m.selections, m.theme, fallback, m.filters)
+				saveConfig(m.config())
+				m.message = fmt.Sprintf("Applied %s to %d characters (others: %s).", m.theme, n, fallback)
+				m.currentMenu = mainMenu
+				m.cursor = 0
+				m.page = 0
+			default:
+				m.pageMove(key)
+			}
+
+		case filterMenu:
+			switch key {
+			case "q":
+				m.currentMenu = mainMenu
+				m.cursor = 0
+				m.page = 0
+			case "enter", " ":
+				f := contentFilters[m.page*itemsPerPage+m.cursor]
+				m.filters[f] = !m.filters[f]
+				if !m.filters[f] {
+					delete(m.filters, f)
+				}
+				saveConfig(m.config())
+				m.options = m.filterOptions()
+			default:
+				m.pageMove(key)
+			}
+
+		case castSwapMenu:
+			switch key {
+			case "q":
+				m.currentMenu = mainMenu
+				m.cursor = 0
+				m.page = 0
+			case "enter", " ":
+				switch choice := m.pageSelection(); choice {
+				case "Shuffle All":
+					m.swaps = shuffleSwaps(spriteChoices)
+					m.message = "Shuffled every character's sprites."
+				case "Clear All":
+					m.swaps = make(map[string]string)
+					m.message = "Every character uses their own sprites again."
+				default:
+					source := strings.TrimPrefix(choice, "Everyone as ")
+					m.swaps = everyoneAs(spriteChoices, source)
+					m.message = fmt.Sprintf("Everyone now uses %s's sprites.", source)
+				}
+				saveConfig(m.config())
+				m.currentMenu = mainMenu
+				m.cursor = 0
+				m.page = 0
+			default:
+				m.pageMove(key)
+			}
+
+		case expressionMenu:
+			switch key {
+			case "q":
+				m.currentMenu = characterMenu
+				m.cursor = 0
+			case "up", "k":
+				m.move(len(expressionModes), true)
+			case "down", "j":
+				m.move(len(expressionModes), false)
+			case "enter", " ":
+				m.expressions[m.selectedCharacter] = expressionModes[m.cursor]
+				saveConfig(m.config())
+				m.message = fmt.Sprintf("Selected %s → Expressions → %s", m.selectedCharacter, expressionModes[m.cursor])
+				m.currentMenu = spriteMenu
 			}
 
 		case meiVariantMenu:
@@ -320,6 +873,15 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.currentMenu = characterMenu
 				m.cursor = 0
 				m.page = 0
+				if m.preview {
+					return m, clearPreview()
+				}
+			case "p":
+				m.preview = !m.preview
+				saveConfig(m.config())
+				if !m.preview {
+					return m, clearPreview()
+				}
 			case "up", "k":
 				m.move(len(visible), true)
 			case "down", "j":
@@ -350,23 +912,25 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					idx = len(m.meiOptions) - 1
 				}
 				chosen := m.meiOptions[idx]
+				if outfitMissing(m.selectedCharacter, chosen) {
+					m.message = fmt.Sprintf("%s is not installed for %s.", chosen, m.selectedCharacter)
+					break
+				}
 
 				
 var variant string
 switch chosen {
 case "Best Match":
-    data := Characters[m.selectedCharacter]
-    if len(data.OutfitsMei) > 0 {
-        variant = data.OutfitsMei[0].SpriteSet
-        chosen = data.OutfitsMei[0].Name
+    if o, ok := bestMatch(m.selectedCharacter, m.filters); ok {
+        variant = o.SpriteSet
+        chosen = o.Name
     } else {
-        variant = spriteSets[0]
+        variant = defaultVariant(meiFolder(m.selectedCharacter))
     }
-case "Random Outfits", "Random Outfits & Expressions":
+case "Random Outfits", "Random Outfits & Expressions", keepOriginal:
     variant = "" 
 default:
-    data := Characters[m.selectedCharacter]
-    for _, o := range data.OutfitsMei {
+    for _, o := range installedOutfits(m.selectedCharacter) {
         if o.Name == chosen {
             variant = o.SpriteSet
             break
@@ -380,13 +944,12 @@ if variant != "" {
     m.selections[m.selectedCharacter] = chosen
 }
 
-saveConfig(Config{
-    GamePath:   m.filePath,
-    SpritePath: m.spritePath,
-    Selections: m.selections,
-})
+saveConfig(m.config())
 m.message = fmt.Sprintf("Selected %s → Mei → %s", m.selectedCharacter, m.selections[m.selectedCharacter])
 m.currentMenu = spriteMenu
+if m.preview {
+    return m, clearPreview()
+}
 
 			}
 		case checkSelectionsMenu:
@@ -424,7 +987,7 @@ m.currentMenu = spriteMenu
 	return m, nil
 }
 
-func (m model) randomizeSprites() (tea.Model, tea.Cmd) {
+func (m model) restoreOriginalSprites() (tea.Model, tea.Cmd) {
     if m.spritePath == "" {
         m.message = "Select a game first."
         return m, nil
@@ -433,117 +996,18 @@ func (m model) randomizeSprites() (tea.Model, tea.Cmd) {
     spriteDir := m.spritePath
     backupDir := filepath.Join(filepath.Dir(spriteDir), "sprite_backup")
 
-    if _, err := os.Stat(backupDir); os.IsNotExist(err) {
-        log.Println("Creating backup at:", backupDir)
-        filepath.Walk(spriteDir, func(path string, info os.FileInfo, err error) error {
-            if err != nil {
-                return nil
-            }
-            if !info.IsDir() && filepath.Ext(path) == ".png" {
-                rel, _ := filepath.Rel(spriteDir, path)
-                dst := filepath.Join(backupDir, rel)
-                os.MkdirAll(filepath.Dir(dst), 0755)
-                data, _ := os.ReadFile(path)
-                os.WriteFile(dst, data, 0644)
-            }
-            return nil
-        })
-    }
-
-for key := range RawGameSprites {
-    dst := filepath.Join(spriteDir, key+".png")
-    if _, err := os.Stat(dst); err != nil {
-        continue 
-    }
-
-    folder := GetFolder(key)
-    selection := m.selections[folder]
-
-    var chosenVariant string
-    var chosenExpression string
-
-    switch selection {
-    case "Random Outfits":
-        data := Characters[folder]
-        if len(data.OutfitsMei) > 0 {
-            o := data.OutfitsMei[rand.Intn(len(data.OutfitsMei))]
-            chosenVariant = o.SpriteSet
-            chosenExpression = RawGameSprites[key][0] // preserve the original expression
-        } else {
-            chosenVariant = spriteSets[0]
-            chosenExpression = RawGameSprites[key][0]
-        }
-    case "Random Outfits & Expressions":
-        data := Characters[folder]
-        if len(data.OutfitsMei) > 0 {
-            o := data.OutfitsMei[rand.Intn(len(data.OutfitsMei))]
-            chosenVariant = o.SpriteSet
-
-            variantFolder := filepath.Join("sprites", "mei", folder, chosenVariant)
-            files, err := os.ReadDir(variantFolder)
-            if err != nil || len(files) == 0 {
-                chosenExpression = RawGameSprites[key][0] // fallback
-            } else {
-                pngFiles := []string{}
-                for _, f := range files {
-                    if !f.IsDir() && filepath.Ext(f.Name()) == ".png" {
-                        pngFiles = append(pngFiles, strings.TrimSuffix(f.Name(), ".png"))
-                    }
-                }
-                if len(pngFiles) > 0 {
-                    chosenExpression = pngFiles[rand.Intn(len(pngFiles))]
-                } else {
-                    chosenExpression = RawGameSprites[key][0]
-                }
-            }
-        } else {
-            chosenVariant = spriteSets[0]
-            chosenExpression = RawGameSprites[key][0]
+    // with an overlay mounted the originals were never touched, so switch
+    // them back in
+    if overlay := overlayFor(spriteDir); overlay.Mounted() {
+        if err := overlay.Switch(false, m.overlayMode()); err != nil {
+            log.Printf("Could not switch sprite folders: %v", err)
+            m.message = "Could not switch the original sprites back in."
+            return m, nil
         }
-    default:
-        if start := strings.LastIndex(selection, "(variant: "); start != -1 {
-            end := strings.Index(selection[start:], ")")
-            if end != -1 {
-                chosenVariant = selection[start+10 : start+end]
-            }
-        }
-        if chosenVariant == "" {
-            chosenVariant = spriteSets[0]
-        }
-        chosenExpression = RawGameSprites[key][0]
-    }
-
-    src := filepath.Join("sprites", "mei", folder, chosenVariant, chosenExpression+".png")
-    data, err := os.ReadFile(src)
-    if err != nil {
-        log.Printf("Could not read Mei sprite: %s", src)
-        continue
-    }
-
-    err = os.WriteFile(dst, data, 0644)
-    if err != nil {
-        log.Printf("Could not write sprite: %s", dst)
-        continue
-    }
-
-    log.Printf("Replaced: %s → %s (variant: %s, expression: %s)", key, dst, chosenVariant, chosenExpression)
-}
-
-
-    m.message = "Sprites randomized successfully."
-    return m, nil
-}
-
-
-func (m model) restoreOriginalSprites() (tea.Model, tea.Cmd) {
-    if m.spritePath == "" {
-        m.message = "Select a game first."
+        m.message = "Original sprites restored successfully."
         return m, nil
     }
 
-    spriteDir := m.spritePath
-    backupDir := filepath.Join(filepath.Dir(spriteDir), "sprite_backup")
-
     if _, err := os.Stat(backupDir); os.IsNotExist(err) {
         m.message = "No backup found. You must randomize once before restoring."
         return m, nil
@@ -555,8 +1019,7 @@ func (m model) restoreOriginalSprites() (tea.Model, tea.Cmd) {
             rel, _ := filepath.Rel(backupDir, path)
             dst := filepath.Join(spriteDir, rel)
             os.MkdirAll(filepath.Dir(dst), 0755)
-            data, _ := os.ReadFile(path)
-            os.WriteFile(dst, data, 0644)
+            deployFile(path, dst, false)
         }
         return nil
     })
@@ -565,18 +1028,107 @@ func (m model) restoreOriginalSprites() (tea.Model, tea.Cmd) {
     return m, nil
 }
 
+// Returns how the overlay folders are swapped; writing in place still
+// swaps an existing overlay by rename
+func (m model) overlayMode() string {
+	if m.output == outputInPlace {
+		return outputRename
+	}
+	return m.output
+}
+
+// Switches between the vanilla and randomized overlay folders
+func (m model) toggleOverlay() (tea.Model, tea.Cmd) {
+	if m.spritePath == "" {
+		m.message = "Select a game first."
+		return m, nil
+	}
+
+	overlay := overlayFor(m.spritePath)
+	if !exists(overlay.Randomized) && !overlay.Active() {
+		m.message = "No randomized folder yet. Randomize with an overlay output mode first."
+		return m, nil
+	}
+	// with nothing mounted the game's folder is renamed to sprite_vanilla,
+	// which it mustn't be while it still holds an in place run
+	if !overlay.Mounted() {
+		if last, ok := loadHistory(historyDir(m.spritePath)).LastRun(); ok && last.Output == outputInPlace {
+			if files, err := hashSprites(m.spritePath); err != nil || maps.Equal(files, last.Files) {
+				m.message = "The sprites were randomized in place. Restore the original sprites first."
+				return m, nil
+			}
+		}
+	}
+	randomized := !overlay.Active()
+	if err := overlay.Switch(randomized, m.overlayMode()); err != nil {
+		log.Printf("Could not switch sprite folders: %v", err)
+		m.message = "Could not switch sprite folders."
+		return m, nil
+	}
+	if randomized {
+		m.message = "Randomized sprites switched in."
+	} else {
+		m.message = "Original sprites switched in."
+	}
+	return m, nil
+}
+
+func (m model) scanGameScripts() (tea.Model, tea.Cmd) {
+	if m.spritePath == "" {
+		m.message = "Select a game first."
+		return m, nil
+	}
+
+	usage, err := ScanScripts(m.spritePath, m.chapter)
+	if err != nil {
+		log.Printf("Could not scan scripts: %v", err)
+		m.message = "Could not scan game scripts."
+		return m, nil
+	}
+
+	report := "script-coverage.txt"
+	if m.chapter != "" {
+		report = fmt.Sprintf("script-coverage-%s.txt", strings.TrimSuffix(m.chapter, "+"))
+	}
+	if err := usage.WriteReport(report); err != nil {
+		log.Printf("Could not write %s: %v", report, err)
+	}
+
+	m.message = fmt.Sprintf("Scanned %d scripts: %d sprites used, %d unmapped, %d mapped but unused (see %s).",
+		usage.Scripts, len(usage.Used), len(usage.Unmapped()), len(usage.Unused()), report)
+	return m, nil
+}
+
+// Draws the current menu, first taking down a kitty preview the variant
+// menu left on screen
 func (m model) View() string {
+	if previewProtocol() == previewKitty && (m.currentMenu != meiVariantMenu || !m.preview) {
+		return kittyDelete() + m.menuView()
+	}
+	return m.menuView()
+}
+
+func (m model) menuView() string {
 	if m.quitting {
 		return "Goodbye!\n"
 	}
 
 	switch m.currentMenu {
+	case progressMenu:
+		return m.progressView()
+
+	case statusMenu:
+		return m.status.View()
+
 	case mainMenu:
-		return fmt.Sprintf(
-			"Main Menu\n\n%s Select Game\n%s Select Sprites\n%s Check Selections\n%s Randomize\n%s Restore Original Sprites\n%s Exit\n\n%s\n",
-			cursor(m.cursor, 0), cursor(m.cursor, 1), cursor(m.cursor, 2), cursor(m.cursor, 3), cursor(m.cursor, 4), cursor(m.cursor, 5),
-			m.message,
-		)
+		s := "Main Menu\n\n"
+		for i, item := range mainMenuItems {
+			if item == "Output Mode" {
+				item += ": " + m.output
+			}
+			s += fmt.Sprintf("%s %s\n", cursor(m.cursor, i), item)
+		}
+		return s + "\n" + m.message + "\n"
 
 
 
@@ -608,15 +1160,52 @@ func (m model) View() string {
 
 		s := fmt.Sprintf("Select Character (Page %d)\n\n", m.page+1)
 		for i, name := range spriteChoices[start:end] {
-			s += fmt.Sprintf("%s %s\n", cursor(m.cursor, i), name)
+			if m.chapter != "" {
+				s += fmt.Sprintf("%s %s: %d sprites in this chapter\n", cursor(m.cursor, i), name, m.counts[name])
+			} else {
+				s += fmt.Sprintf("%s %s\n", cursor(m.cursor, i), name)
+			}
 		}
 		return s + "\nUse ↑↓ ←→ Enter, q to return.\n"
 
 	case characterMenu:
 		return fmt.Sprintf(
-			"Character: %s\n\n%s Mei\n%s Ace Attorney\n\nUse ↑↓ Enter, q to return.\n",
-			m.selectedCharacter, cursor(m.cursor, 0), cursor(m.cursor, 1),
+			"Character: %s\n\n%s Mei\n%s Ace Attorney\n%s Expressions\n%s Use Sprites Of\n%s Calibrate\n%s Image Effects\n\nUse ↑↓ Enter, q to return.\n",
+			m.selectedCharacter, cursor(m.cursor, 0), cursor(m.cursor, 1), cursor(m.cursor, 2), cursor(m.cursor, 3), cursor(m.cursor, 4), cursor(m.cursor, 5),
+		)
+	case effectMenu:
+		if m.effectTarget == "" {
+			return m.pageView("Image Effects (everyone)")
+		}
+		return m.pageView(fmt.Sprintf("Image Effects (%s)", m.effectTarget))
+	case calibrationMenu:
+		target := m.calibrationTarget()
+		scope := "all outfits"
+		if target != m.selectedCharacter {
+			scope = "outfit " + strings.TrimPrefix(target, m.selectedCharacter+"/")
+		}
+		cal := m.currentCalibration()
+		return fmt.Sprintf(
+			"Calibrate %s (%s)\n\n%s, anchored on the %s\n\nUse + - to scale, ↑↓←→ to move, a to switch anchor, o to switch outfit/character, r to reset, q to return.\n",
+			m.selectedCharacter, scope, cal, cal.anchor(m.selectedCharacter),
 		)
+	case swapMenu:
+		return m.pageView(fmt.Sprintf("Use Sprites Of (%s)", m.selectedCharacter))
+	case castSwapMenu:
+		return m.pageView("Character Swaps")
+	case themeMenu:
+		return m.pageView("Costume Themes")
+	case filterMenu:
+		return m.pageView("Content Filters")
+	case themeFallbackMenu:
+		return m.pageView(fmt.Sprintf("Without %s", m.theme))
+	case expressionMenu:
+		s := fmt.Sprintf("Expressions (%s)\n\n", m.selectedCharacter)
+		for i, mode := range expressionModes {
+			s += fmt.Sprintf("%s %s\n", cursor(m.cursor, i), mode)
+		}
+		s += "\nTalking poses keep their eyes in step with the mouth, so\n" + exprBlushAndEyes + " only re-rolls their blush.\n"
+		return s + "\nUse ↑↓ Enter, q to return.\n"
 	case meiVariantMenu:
 		if len(m.meiOptions) == 0 {
 			return "No options available.\n"
@@ -638,9 +1227,24 @@ func (m model) View() string {
 
 		s := fmt.Sprintf("Mei Variant (%s) Page %d\n\n", m.selectedCharacter, m.page+1)
 		for i, name := range m.meiOptions[start:end] {
+			if outfitMissing(m.selectedCharacter, name) {
+				s += missingStyle.Render(fmt.Sprintf("%s %s (not installed)", cursor(m.cursor, i), name)) + "\n"
+				continue
+			}
 			s += fmt.Sprintf("%s %s\n", cursor(m.cursor, i), name)
 		}
-		return s + "\nUse ↑↓ ←→ Enter, q to return.\n"
+		if m.preview {
+			s += "\n"
+			idx := start + m.cursor
+			if idx >= 0 && idx < len(m.meiOptions) {
+				if folder, variant, ok := previewVariant(m.selectedCharacter, m.meiOptions[idx], m.filters); ok {
+					s += renderPreview(folder, variant, previewProtocol())
+				} else {
+					s += noPreview(previewProtocol())
+				}
+			}
+		}
+		return s + "\nUse ↑↓ ←→ Enter, p to toggle preview, q to return.\n"
 
 	case checkSelectionsMenu:
 		start := m.page * itemsPerPage
@@ -652,6 +1256,12 @@ func (m model) View() string {
 		s := fmt.Sprintf("Current Selections (Page %d)\n\n", m.page+1)
 		for i, c := range spriteChoices[start:end] {
 			selection := m.selections[c]
+			if mode := m.expressions[c]; mode != "" && mode != exprOriginal {
+				selection += fmt.Sprintf(" (%s)", mode)
+			}
+			if source := swapSource(m.swaps, c); source != c {
+				selection += fmt.Sprintf(" as %s", source)
+			}
 			s += fmt.Sprintf("%s %s → %s\n", cursor(m.cursor, i), c, selection)
 		}
 		return s + "\nUse ↑↓ ←→ q to return.\n"
@@ -662,6 +1272,14 @@ func (m model) View() string {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "watch" {
+		if err := runWatch(); err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	p := tea.NewProgram(initialModel())
 	if _, err := p.Run(); err != nil {
 		fmt.Println("Error:", err)
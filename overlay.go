@@ -0,0 +1,188 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// where a randomization is written
+const (
+	outputInPlace = "In Place"          // overwrite the live sprite folder, originals in sprite_backup
+	outputRename  = "Overlay (rename)"  // build a separate folder and rename it into place
+	outputSymlink = "Overlay (symlink)" // build a separate folder and point a sprite symlink at it
+)
+
+var outputModes = []string{outputInPlace, outputRename, outputSymlink}
+
+// Overlay is the set of folders next to CGAlt/sprite used by the overlay
+// modes. Whichever set the game should see is renamed to (or linked as)
+// sprite; the other keeps its own name, so neither is ever overwritten.
+type Overlay struct {
+	Sprite     string // what the game loads
+	Vanilla    string // the game's own sprites while the randomized set is in use
+	Randomized string // the last randomized set
+}
+
+func overlayFor(spriteDir string) Overlay {
+	return Overlay{
+		Sprite:     spriteDir,
+		Vanilla:    spriteDir + "_vanilla",
+		Randomized: spriteDir + "_randomized",
+	}
+}
+
+func exists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+func isSymlink(path string) bool {
+	info, err := os.Lstat(path)
+	return err == nil && info.Mode()&os.ModeSymlink != 0
+}
+
+// Reports whether the overlay folders are in use at all
+func (o Overlay) Present() bool {
+	return isSymlink(o.Sprite) || exists(o.Vanilla) || exists(o.Randomized)
+}
+
+// Reports whether the game's own folder is swapped out, renamed to
+// sprite_vanilla or behind a sprite symlink
+func (o Overlay) Mounted() bool {
+	return isSymlink(o.Sprite) || exists(o.Vanilla)
+}
+
+// Reports whether the game currently sees the randomized set
+func (o Overlay) Active() bool {
+	if isSymlink(o.Sprite) {
+		target, err := os.Readlink(o.Sprite)
+		return err == nil && filepath.Base(target) == filepath.Base(o.Randomized)
+	}
+	return exists(o.Vanilla)
+}
+
+// Returns the output mode the sprite folder is in and, for the overlay
+// modes, whether the randomized set is in place
+func (o Overlay) State() (string, bool) {
+	switch {
+	case isSymlink(o.Sprite):
+		return outputSymlink, o.Active()
+	case o.Present():
+		return outputRename, o.Active()
+	}
+	return outputInPlace, false
+}
+
+// Returns the folder holding the game's own sprites
+func (o Overlay) Originals() string {
+	if isSymlink(o.Sprite) || exists(o.Vanilla) {
+		return o.Vanilla
+	}
+	return o.Sprite
+}
+
+// Moves whatever is in place back to its own name, leaving no sprite folder
+func (o Overlay) unmount() error {
+	switch {
+	case isSymlink(o.Sprite):
+		return os.Remove(o.Sprite)
+	case !exists(o.Sprite):
+		return nil
+	case exists(o.Vanilla):
+		return os.Rename(o.Sprite, o.Randomized)
+	default:
+		return os.Rename(o.Sprite, o.Vanilla)
+	}
+}
+
+// Puts the vanilla or randomized set in place, by rename or by symlink
+func (o Overlay) mount(randomized bool, mode string) error {
+	target := o.Vanilla
+	if randomized {
+		target = o.Randomized
+	}
+	if mode == outputSymlink {
+		// relative, so the game folder can be moved
+		return os.Symlink(filepath.Base(target), o.Sprite)
+	}
+	return os.Rename(target, o.Sprite)
+}
+
+// Switches the game to the vanilla or randomized set. If the switch
+// fails halfway the vanilla set is put back.
+func (o Overlay) Switch(randomized bool, mode string) error {
+	if err := o.unmount(); err != nil {
+		return err
+	}
+	if err := o.mount(randomized, mode); err != nil {
+		o.mount(false, outputRename)
+		return err
+	}
+	return nil
+}
+
+// Puts the game's own folder back under its name and drops the
+// randomized set, before a run writes into the game's folder in place.
+// A randomized set left next to in place sprites would otherwise be
+// switched in, and the in place sprites taken for the originals.
+func (o Overlay) Retire() error {
+	if !o.Present() {
+		return nil
+	}
+	if err := o.Switch(false, outputRename); err != nil {
+		return err
+	}
+	return os.RemoveAll(o.Randomized)
+}
+
+// Replaces the randomized set with a freshly built folder and switches
+// the game to it
+func (o Overlay) Install(staging, mode string) error {
+	if err := o.unmount(); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(o.Randomized); err != nil {
+		o.mount(false, outputRename)
+		return err
+	}
+	if err := os.Rename(staging, o.Randomized); err != nil {
+		o.mount(false, outputRename)
+		return err
+	}
+	return o.Switch(true, mode)
+}
+
+// Copies every file under src to dst, used to seed an overlay build with
+// the sprites a run doesn't replace
+func copyTree(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(src, path)
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		_, err = deployFile(path, target, false)
+		return err
+	})
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
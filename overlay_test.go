@@ -0,0 +1,51 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestRestoreAfterOverlayThenInPlace(t *testing.T) {
+	t.Chdir(t.TempDir())
+	spriteDir, _ := filepath.Abs(filepath.Join("CGAlt", "sprite"))
+	sprite := filepath.Join(spriteDir, "a.png")
+	writeSprite(t, sprite, "vanilla a")
+	writeSprite(t, filepath.Join(filepath.Dir(spriteDir), "sprite_backup", "a.png"), "vanilla a")
+
+	// an overlay run
+	overlay := overlayFor(spriteDir)
+	staging := filepath.Join(t.TempDir(), "staging")
+	writeSprite(t, filepath.Join(staging, "a.png"), "overlay a")
+	if err := overlay.Install(staging, outputRename); err != nil {
+		t.Fatal(err)
+	}
+
+	// then an in place run
+	if err := overlay.Retire(); err != nil {
+		t.Fatal(err)
+	}
+	if overlay.Present() {
+		t.Fatal("in place run left the overlay folders behind")
+	}
+	writeSprite(t, sprite, "in place a")
+	cache := openSpriteCache(spriteCacheDir)
+	run, err := snapshot(spriteDir, cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	run.Run = &RunLog{}
+	if err := loadHistory(historyDir(spriteDir)).Push(run, cache); err != nil {
+		t.Fatal(err)
+	}
+
+	m := model{spritePath: spriteDir, output: outputInPlace}
+	next, _ := m.toggleOverlay()
+	if exists(overlay.Vanilla) {
+		t.Errorf("toggle took the in place sprites for the originals: %q", next.(model).message)
+	}
+
+	next, _ = m.restoreOriginalSprites()
+	if got := readSprite(t, sprite); got != "vanilla a" {
+		t.Errorf("restore left a.png = %q (%s)", got, next.(model).message)
+	}
+}
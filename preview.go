@@ -0,0 +1,268 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ways of drawing an image in the terminal
+const (
+	previewBlocks = "blocks" // half-block characters with 24-bit colour, works anywhere
+	previewKitty  = "kitty"
+	previewITerm  = "iterm"
+	previewSixel  = "sixel"
+)
+
+// size of the preview pane in terminal cells
+const (
+	previewCols = 24
+	previewRows = 16
+)
+
+// kitty image id of the preview, so each new preview replaces the last
+// instead of stacking placements
+const previewImageID = 4207
+
+// Picks the graphics protocol from HIGURANDOMIZER_PREVIEW, or from what
+// the terminal announces about itself. Sixel support can't be told from
+// the environment, so it has to be asked for.
+func previewProtocol() string {
+	switch p := os.Getenv("HIGURANDOMIZER_PREVIEW"); p {
+	case previewBlocks, previewKitty, previewITerm, previewSixel:
+		return p
+	}
+	if os.Getenv("KITTY_WINDOW_ID") != "" || os.Getenv("TERM") == "xterm-kitty" {
+		return previewKitty
+	}
+	if os.Getenv("TERM_PROGRAM") == "iTerm.app" {
+		return previewITerm
+	}
+	return previewBlocks
+}
+
+// rendered previews by folder/variant, so scrolling doesn't re-decode
+var previewCache = make(map[string]string)
+
+// Renders a variant's normal_open sprite for the preview pane
+func renderPreview(folder, variant, protocol string) string {
+	cacheKey := folder + "/" + variant + "/" + protocol
+	if s, ok := previewCache[cacheKey]; ok {
+		return s
+	}
+
+	data, err := readMeiSprite(folder, variant, normal_open)
+	if err != nil {
+		return noPreview(protocol)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return noPreview(protocol)
+	}
+
+	var s string
+	switch protocol {
+	case previewKitty:
+		s = kittyImage(data)
+	case previewITerm:
+		s = itermImage(data)
+	case previewSixel:
+		s = sixelImage(fitPreview(img, previewCols*8, previewRows*12), previewCols*8, previewRows*12)
+	default:
+		s = halfBlocks(fitPreview(img, previewCols, previewRows*2))
+	}
+	previewCache[cacheKey] = s
+	return s
+}
+
+// Takes down whatever image the preview pane showed before and says
+// there is nothing to show
+func noPreview(protocol string) string {
+	switch protocol {
+	case previewKitty:
+		return kittyDelete() + "(no preview)\n"
+	case previewSixel:
+		return sixelImage(image.NewNRGBA(image.Rect(0, 0, 1, 1)), previewCols*8, previewRows*12) + "(no preview)\n"
+	}
+	return "(no preview)\n"
+}
+
+// Deletes the preview image and its placements from a kitty terminal
+func kittyDelete() string {
+	return fmt.Sprintf("\x1b_Ga=d,d=I,i=%d,q=2\x1b\\", previewImageID)
+}
+
+// Clears the sixel pixels a preview leaves behind once it goes away;
+// unlike text they stay on screen until the terminal is cleared
+func clearPreview() tea.Cmd {
+	if previewProtocol() == previewSixel {
+		return tea.ClearScreen
+	}
+	return nil
+}
+
+// Crops to the figure and scales it to fit w×h pixels
+func fitPreview(img image.Image, w, h int) *image.NRGBA {
+	figure := opaqueBounds(img)
+	if figure.Empty() {
+		figure = img.Bounds()
+	}
+	src := crop(img, figure)
+
+	sw, sh := figure.Dx(), figure.Dy()
+	if sw*h > sh*w {
+		h = sh * w / sw
+	} else {
+		w = sw * h / sh
+	}
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+	return resize(src, w, h)
+}
+
+// Draws two pixel rows per line with ▀, foreground on top and
+// background below, leaving transparent pixels to the terminal
+func halfBlocks(img *image.NRGBA) string {
+	var b strings.Builder
+	bounds := img.Bounds()
+	for y := 0; y < bounds.Dy(); y += 2 {
+		for x := 0; x < bounds.Dx(); x++ {
+			top := img.NRGBAAt(x, y)
+			bottom := color.NRGBA{}
+			if y+1 < bounds.Dy() {
+				bottom = img.NRGBAAt(x, y+1)
+			}
+			switch {
+			case top.A < 128 && bottom.A < 128:
+				b.WriteString("\x1b[0m ")
+			case bottom.A < 128:
+				fmt.Fprintf(&b, "\x1b[0m\x1b[38;2;%d;%d;%dm▀", top.R, top.G, top.B)
+			case top.A < 128:
+				fmt.Fprintf(&b, "\x1b[0m\x1b[38;2;%d;%d;%dm▄", bottom.R, bottom.G, bottom.B)
+			default:
+				fmt.Fprintf(&b, "\x1b[38;2;%d;%d;%dm\x1b[48;2;%d;%d;%dm▀",
+					top.R, top.G, top.B, bottom.R, bottom.G, bottom.B)
+			}
+		}
+		b.WriteString("\x1b[0m\n")
+	}
+	return b.String()
+}
+
+// Sends the PNG over the kitty graphics protocol in 4096 byte chunks,
+// leaving the cursor in place and padding below for the image. The
+// previous preview is deleted first and responses are suppressed.
+func kittyImage(data []byte) string {
+	enc := base64.StdEncoding.EncodeToString(data)
+	var b strings.Builder
+	b.WriteString(kittyDelete())
+	for i := 0; i < len(enc); i += 4096 {
+		end := i + 4096
+		more := 1
+		if end >= len(enc) {
+			end = len(enc)
+			more = 0
+		}
+		if i == 0 {
+			fmt.Fprintf(&b, "\x1b_Gf=100,a=T,i=%d,q=2,C=1,c=%d,r=%d,m=%d;%s\x1b\\", previewImageID, previewCols, previewRows, more, enc[i:end])
+		} else {
+			fmt.Fprintf(&b, "\x1b_Gm=%d;%s\x1b\\", more, enc[i:end])
+		}
+	}
+	return b.String() + strings.Repeat("\n", previewRows)
+}
+
+func itermImage(data []byte) string {
+	return fmt.Sprintf("\x1b]1337;File=inline=1;width=%d;height=%d;preserveAspectRatio=1:%s\a\n",
+		previewCols, previewRows, base64.StdEncoding.EncodeToString(data))
+}
+
+// Encodes the image as sixels on a 6×6×6 colour cube over a w×h pane.
+// Transparent pixels and the rest of the pane are painted with the
+// background, so a smaller image fully covers the one before it.
+func sixelImage(img *image.NRGBA, paneW, paneH int) string {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+
+	index := func(c color.NRGBA) int {
+		if c.A < 128 {
+			return -1
+		}
+		return int(c.R)*6/256*36 + int(c.G)*6/256*6 + int(c.B)*6/256
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "\x1bP0;0q\"1;1;%d;%d", paneW, paneH)
+	for i := 0; i < 216; i++ {
+		fmt.Fprintf(&b, "#%d;2;%d;%d;%d", i, (i/36)*20, (i/6%6)*20, (i%6)*20)
+	}
+
+	for band := 0; band < max(h, paneH); band += 6 {
+		used := make(map[int]bool)
+		for y := band; y < band+6 && y < h; y++ {
+			for x := 0; x < w; x++ {
+				if i := index(img.NRGBAAt(x, y)); i >= 0 {
+					used[i] = true
+				}
+			}
+		}
+		for i := range used {
+			fmt.Fprintf(&b, "#%d", i)
+			run, last := 0, byte(0)
+			flush := func() {
+				if run > 3 {
+					fmt.Fprintf(&b, "!%d%c", run, last)
+				} else {
+					b.WriteString(strings.Repeat(string(last), run))
+				}
+			}
+			for x := 0; x < w; x++ {
+				bits := 0
+				for dy := 0; dy < 6 && band+dy < h; dy++ {
+					if index(img.NRGBAAt(x, band+dy)) == i {
+						bits |= 1 << dy
+					}
+				}
+				ch := byte(63 + bits)
+				if run > 0 && ch != last {
+					flush()
+					run = 0
+				}
+				last = ch
+				run++
+			}
+			flush()
+			b.WriteString("$")
+		}
+		b.WriteString("-")
+	}
+	b.WriteString("\x1b\\\n")
+	return b.String()
+}
+
+// Returns the Mei folder and variant a menu option would preview as;
+// Best Match and the random options show the first outfit the filters allow
+func previewVariant(character, option string, filters map[string]bool) (string, string, bool) {
+	folder := meiFolder(character)
+	for _, o := range installedOutfits(character) {
+		if o.Name == option {
+			return folder, o.SpriteSet, true
+		}
+	}
+	if option == keepOriginal || outfitMissing(character, option) {
+		return folder, "", false
+	}
+	o, ok := bestMatch(character, filters)
+	return folder, o.SpriteSet, ok
+}
@@ -0,0 +1,517 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// number of sprites worked on at once
+var randomizeWorkers = runtime.NumCPU()
+
+// Runs work(i) for every i in [0, n) on at most workers goroutines
+func parallel(n, workers int, work func(i int)) {
+	if workers < 1 {
+		workers = 1
+	}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				work(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// Returns the random source for one key of a run, so a run's seed gives
+// the same sprites whatever order the workers take the keys in
+func keyRand(seed int64, key string) *rand.Rand {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return rand.New(rand.NewSource(seed ^ int64(h.Sum64())))
+}
+
+// Copies the game's own PNGs into the backup folder
+func createBackup(src, dst string) error {
+	var files []string
+	filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() && filepath.Ext(path) == ".png" {
+			files = append(files, path)
+		}
+		return nil
+	})
+
+	errs := make([]error, len(files))
+	parallel(len(files), randomizeWorkers, func(i int) {
+		rel, _ := filepath.Rel(src, files[i])
+		target := filepath.Join(dst, rel)
+		if errs[i] = os.MkdirAll(filepath.Dir(target), 0755); errs[i] == nil {
+			errs[i] = copyFile(files[i], target)
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// randomizeRun is what the workers of one run share
+type randomizeRun struct {
+	outDir    string
+	backupDir string
+	journal   *Journal // records what the run replaces, nil when building an overlay
+	seed      int64
+	cache     *SpriteCache
+
+	mu       sync.Mutex
+	writeErr error              // first sprite that couldn't be written
+	abort    context.CancelFunc // stops the other workers after a failed write
+}
+
+// randomizeProgressMsg reports how far a run in the background has got
+type randomizeProgressMsg struct {
+	done      int
+	total     int
+	character string // character of the sprite finished last
+	failures  int
+}
+
+// randomizeDoneMsg ends a run with the message for the main menu
+type randomizeDoneMsg struct {
+	message string
+}
+
+// Returns a command delivering the next message of a background run
+func waitForRun(updates <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-updates
+	}
+}
+
+// Starts a run in the background and switches to the progress view.
+// The same seed and selections give the same sprites.
+func (m model) randomizeSprites(seed int64) (tea.Model, tea.Cmd) {
+	if m.spritePath == "" {
+		m.message = "Select a game first."
+		return m, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	updates := make(chan tea.Msg, 16)
+	go func() {
+		updates <- randomizeDoneMsg{m.runRandomize(ctx, updates, seed, nil)}
+	}()
+
+	m.cancelRun = cancel
+	m.runUpdates = updates
+	m.progress = randomizeProgressMsg{}
+	m.currentMenu = progressMenu
+	return m, waitForRun(updates)
+}
+
+// Randomizes every sprite of the chapter, or only those only accepts
+// when it isn't nil, sending progress on updates. If ctx is cancelled or
+// any sprite can't be written, everything the run wrote is rolled back.
+// Returns the message for the main menu.
+func (m model) runRandomize(ctx context.Context, updates chan<- tea.Msg, seed int64, only func(key string) bool) string {
+	spriteDir := m.spritePath
+	backupDir := filepath.Join(filepath.Dir(spriteDir), "sprite_backup")
+
+	// left by a run that never finished: put back what it replaced
+	if dir := journalDir(spriteDir); exists(dir) {
+		log.Printf("Rolling back unfinished run from %s", dir)
+		if err := rollbackJournal(dir, spriteDir); err != nil {
+			log.Printf("Could not roll back unfinished run: %v", err)
+			return "An unfinished run could not be rolled back."
+		}
+		os.RemoveAll(dir)
+	}
+
+	// a game update since the backup was made brings new originals
+	refreshed, err := refreshAfterUpdate(spriteDir)
+	if err != nil {
+		log.Printf("Could not check for a game update: %v", err)
+		return "Could not read the sprite folder."
+	}
+	if refreshed > 0 {
+		log.Printf("Refreshed %d sprites in the backup after a game update", refreshed)
+	}
+
+	// the state the run starts from, so it can be undone
+	cache := openSpriteCache(spriteCacheDir)
+	history := loadHistory(historyDir(spriteDir))
+	before, err := snapshot(spriteDir, cache)
+	if err == nil {
+		err = history.Sync(before, cache)
+	}
+	if err != nil {
+		log.Printf("Could not record sprite folder state: %v", err)
+		return "Could not read the sprite folder."
+	}
+
+	overlay := overlayFor(spriteDir)
+	if m.output == outputInPlace {
+		// writing in place again: the game's own folder has to be back first
+		if err := overlay.Retire(); err != nil {
+			log.Printf("Could not restore vanilla sprite folder: %v", err)
+			return "Could not switch the original sprite folder back in."
+		}
+	}
+	originals := overlay.Originals()
+
+	if _, err := os.Stat(backupDir); os.IsNotExist(err) {
+		log.Println("Creating backup at:", backupDir)
+		if err := createBackup(originals, backupDir); err != nil {
+			log.Printf("Could not back up every sprite: %v", err)
+		}
+	}
+
+	journal, err := openJournal(journalDir(spriteDir))
+	if err != nil {
+		log.Printf("Could not start journal: %v", err)
+		os.RemoveAll(journalDir(spriteDir))
+		return "Could not start the run journal."
+	}
+
+	run := RunLog{Time: time.Now(), SpritePath: spriteDir, Seed: seed}
+	workCtx, abort := context.WithCancel(ctx)
+	defer abort()
+	r := &randomizeRun{
+		outDir:    spriteDir,
+		backupDir: backupDir,
+		journal:   journal,
+		seed:      run.Seed,
+		cache:     cache,
+		abort:     abort,
+	}
+
+	// overlay modes build a complete folder next to the game's and swap it in
+	// at the end, so the sprites a run doesn't touch are copied over first
+	if m.output != outputInPlace {
+		// a partial run keeps the rest of the randomized set in use
+		base := originals
+		if only != nil && overlay.Active() {
+			if current, err := filepath.EvalSymlinks(spriteDir); err == nil {
+				base = current
+			}
+		}
+		r.outDir, r.journal = overlay.Randomized+".new", nil
+		os.RemoveAll(r.outDir)
+		if err := copyTree(base, r.outDir); err != nil {
+			log.Printf("Could not prepare %s: %v", r.outDir, err)
+			os.RemoveAll(r.outDir)
+			journal.Close()
+			os.RemoveAll(journal.Dir)
+			return "Could not prepare the overlay folder."
+		}
+	}
+
+	// sorted so the run log comes out in the same order every time
+	var keys []string
+	for key := range RawGameSprites {
+		if exists(filepath.Join(r.outDir, key+".png")) && SpriteInChapter(key, m.chapter) && (only == nil || only(key)) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	run.Results = make([]RunResult, len(keys))
+	methods := make([]string, len(keys))
+	var mu sync.Mutex
+	progress := randomizeProgressMsg{total: len(keys)}
+	parallel(len(keys), randomizeWorkers, func(i int) {
+		if workCtx.Err() != nil {
+			return
+		}
+		run.Results[i], methods[i] = m.randomizeKey(r, keys[i])
+
+		mu.Lock()
+		progress.done++
+		progress.character = run.Results[i].Character
+		if run.Results[i].Status == statusFailed {
+			progress.failures++
+		}
+		p := progress
+		mu.Unlock()
+		// progress is cumulative, so a message the UI is too busy for can be dropped
+		select {
+		case updates <- p:
+		default:
+		}
+	})
+	journal.Close()
+
+	if err := r.cache.Save(); err != nil {
+		log.Printf("Could not write sprite cache index: %v", err)
+	}
+
+	if workCtx.Err() != nil {
+		if r.journal == nil {
+			os.RemoveAll(r.outDir)
+		} else if err := rollbackJournal(journal.Dir, spriteDir); err != nil {
+			log.Printf("Could not roll back run: %v", err)
+			return "The run stopped, but some sprites could not be rolled back."
+		}
+		os.RemoveAll(journal.Dir)
+		if r.writeErr != nil {
+			return fmt.Sprintf("A sprite could not be written (%v); the run was rolled back.", r.writeErr)
+		}
+		return "Randomization cancelled; nothing was changed."
+	}
+
+	deployed := make(map[string]int) // deploy method → files
+	for _, method := range methods {
+		deployed[method]++
+	}
+	log.Printf("Deployed: %d hardlinked, %d reflinked, %d copied", deployed[deployHardlink], deployed[deployReflink], deployed[deployCopy])
+
+	if r.journal == nil {
+		if err := overlay.Install(r.outDir, m.output); err != nil {
+			log.Printf("Could not install overlay: %v", err)
+			os.RemoveAll(r.outDir)
+			os.RemoveAll(journal.Dir)
+			return "Could not swap the randomized sprites in."
+		}
+	}
+
+	os.RemoveAll(journal.Dir)
+
+	if only != nil {
+		// the sprites left alone keep what the last run logged for them
+		if last, err := loadRunLog(runLogFile); err == nil {
+			for _, res := range last.Results {
+				if !only(res.Key) {
+					run.Results = append(run.Results, res)
+				}
+			}
+			sort.Slice(run.Results, func(i, j int) bool { return run.Results[i].Key < run.Results[j].Key })
+		}
+	}
+	if err := saveRunLog(runLogFile, run); err != nil {
+		log.Printf("Could not write %s: %v", runLogFile, err)
+	}
+
+	after, err := snapshot(spriteDir, cache)
+	if err == nil {
+		after.Run = &run
+		err = history.Push(after, cache)
+	}
+	if err != nil {
+		log.Printf("Could not record run in history: %v", err)
+	}
+
+	if failed := run.Counts()[statusFailed]; failed > 0 {
+		return fmt.Sprintf("Sprites randomized with %d failures (see Comparison Report).", failed)
+	}
+	return "Sprites randomized successfully."
+}
+
+// Writes src, a cache object or a backed up original, over a game
+// sprite, journalling the sprite it replaces. A failed write stops the
+// run so it can be rolled back.
+func (r *randomizeRun) write(key, src string, hardlink bool) (string, error) {
+	dst := filepath.Join(r.outDir, key+".png")
+	var err error
+	if r.journal != nil {
+		err = r.journal.Record(key, dst)
+	}
+	method := ""
+	if err == nil {
+		method, err = deployFile(src, dst, hardlink)
+	}
+	if err != nil {
+		r.mu.Lock()
+		if r.writeErr == nil {
+			r.writeErr = err
+		}
+		r.mu.Unlock()
+		r.abort()
+	}
+	return method, err
+}
+
+// Draws the progress of a background run
+func (m model) progressView() string {
+	const width = 30
+	filled := 0
+	if m.progress.total > 0 {
+		filled = width * m.progress.done / m.progress.total
+	}
+	s := "Randomizing...\n\n"
+	s += fmt.Sprintf("[%s%s] %d/%d\n", strings.Repeat("#", filled), strings.Repeat(".", width-filled), m.progress.done, m.progress.total)
+	if m.progress.character != "" {
+		s += "Current: " + m.progress.character + "\n"
+	}
+	if m.progress.failures > 0 {
+		s += fmt.Sprintf("Failures: %d\n", m.progress.failures)
+	}
+	return s + "\nEsc to cancel.\n" + m.message + "\n"
+}
+
+// Picks, processes and writes one game sprite, returning what happened
+// and how the file was deployed. Safe to run from several workers at
+// once; the frames of a talking pose are seeded alike so they all land
+// on the same face.
+func (m model) randomizeKey(r *randomizeRun, key string) (RunResult, string) {
+	dst := filepath.Join(r.outDir, key+".png")
+	character := GetCharacter(key)
+	folder := GetFolder(key)
+	selection := m.selections[character]
+
+	// a swapped character keeps its expression mapping but draws from
+	// the other character's Mei folder and outfit selection
+	source := swapSource(m.swaps, character)
+	if source != character {
+		folder = meiFolder(source)
+		selection = m.selections[source]
+		if selection == keepOriginal {
+			selection = "Best Match"
+		}
+	}
+
+	result := RunResult{Key: key, Character: character, Costume: spriteCostume(key)}
+	failed := func(err error) (RunResult, string) {
+		result.Status, result.Error = statusFailed, err.Error()
+		return result, ""
+	}
+
+	if selection == keepOriginal {
+		// put back the game's own sprite in case an earlier run replaced it
+		result.Status = statusKept
+		src := filepath.Join(r.backupDir, key+".png")
+		if !exists(src) {
+			return result, ""
+		}
+		method, err := r.write(key, src, false)
+		if err != nil {
+			log.Printf("Could not write sprite: %s", dst)
+			return failed(err)
+		}
+		return result, method
+	}
+
+	pose, frame, lipSynced := lipSyncFrame(key)
+	if !lipSynced {
+		pose = key
+	}
+	rng := keyRand(r.seed, pose)
+	pick := m.pickSprite(rng, key, source, folder, selection, m.expressions[character])
+	pick.effects = resolveEffects(rng, effectsFor(m.effects, character))
+
+	chosenVariant := pick.variant
+	chosenExpression := pick.expression
+	if lipSynced {
+		chosenExpression = withMouth(expressionBase(chosenExpression), frame)
+	}
+	result.Variant, result.Expression = chosenVariant, chosenExpression
+
+	data, used, err := resolveMeiSprite(folder, chosenVariant, chosenExpression)
+	if err != nil {
+		log.Printf("Could not read Mei sprite: %s", filepath.Join("sprites", "mei", folder, chosenVariant, chosenExpression+".png"))
+		return failed(err)
+	}
+	result.Used = used
+
+	// fit onto the original's canvas; the backup holds the untouched original
+	original, _ := os.ReadFile(filepath.Join(r.backupDir, key+".png"))
+	cal := calibrationFor(m.calibrations, character, source, pick.variant)
+	cacheKey := spriteCacheKey(original, data, character, cal, pick.effects)
+	cached, ok := r.cache.Get(cacheKey)
+	if !ok {
+		data, err = processSprite(original, data, character, cal, pick.effects)
+		if err == nil {
+			cached, err = r.cache.Put(cacheKey, data)
+		}
+		if err != nil {
+			log.Printf("Could not process sprite %s: %v", key, err)
+			return failed(err)
+		}
+	}
+
+	method, err := r.write(key, cached, true)
+	if err != nil {
+		log.Printf("Could not write sprite: %s", dst)
+		return failed(err)
+	}
+
+	result.Status = statusReplaced
+	if used != chosenExpression {
+		result.Status = statusFallback
+	}
+	log.Printf("Replaced: %s → %s (variant: %s, expression: %s)", key, dst, chosenVariant, used)
+	return result, method
+}
+
+// spritePick is the outfit and expression chosen for a game sprite
+type spritePick struct {
+	variant    string
+	expression string
+	effects    []string
+}
+
+func (m model) pickSprite(rng *rand.Rand, key, character, folder, selection, mode string) spritePick {
+	var chosenVariant string
+	var chosenExpression string
+
+	if _, _, lipSynced := lipSyncFrame(key); lipSynced && mode == exprBlushAndEyes {
+		mode = exprBlushOnly
+	}
+
+	switch selection {
+	case "Random Outfits":
+		outfits := filterOutfits(installedOutfits(character), m.filters)
+		if len(outfits) > 0 {
+			o := outfits[rng.Intn(len(outfits))]
+			chosenVariant = o.SpriteSet
+			chosenExpression = MappedExpression(key) // preserve the original expression
+		} else {
+			chosenVariant = defaultVariant(folder)
+			chosenExpression = MappedExpression(key)
+		}
+	case "Random Outfits & Expressions":
+		outfits := filterOutfits(installedOutfits(character), m.filters)
+		if len(outfits) > 0 {
+			o := outfits[rng.Intn(len(outfits))]
+			chosenVariant = o.SpriteSet
+
+			chosenExpression = randomizeExpression(rng, folder, chosenVariant, MappedExpression(key), exprFullyRandom)
+		} else {
+			chosenVariant = defaultVariant(folder)
+			chosenExpression = MappedExpression(key)
+		}
+	default:
+		chosenVariant = selectionVariant(selection)
+		if chosenVariant == "" {
+			if o, ok := bestMatch(character, m.filters); ok {
+				chosenVariant = o.SpriteSet
+			} else {
+				chosenVariant = defaultVariant(folder)
+			}
+		}
+		chosenExpression = MappedExpression(key)
+	}
+
+	if selection != "Random Outfits & Expressions" {
+		chosenExpression = randomizeExpression(rng, folder, chosenVariant, chosenExpression, mode)
+	}
+
+	return spritePick{variant: chosenVariant, expression: chosenExpression}
+}
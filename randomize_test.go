@@ -0,0 +1,46 @@
+package main
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestParallelPicksFollowSeed(t *testing.T) {
+	saved := PackVariants
+	t.Cleanup(func() { PackVariants = saved })
+	PackVariants = map[string][]string{meiFolder("rena"): spriteSets[:8]}
+
+	var keys []string
+	for _, key := range slices.Sorted(maps.Keys(RawGameSprites)) {
+		if GetCharacter(key) == "rena" {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) < 10 {
+		t.Fatalf("only %d rena sprites", len(keys))
+	}
+
+	picks := func(seed int64, workers int) []spritePick {
+		out := make([]spritePick, len(keys))
+		parallel(len(keys), workers, func(i int) {
+			out[i] = model{}.pickSprite(keyRand(seed, keys[i]), keys[i], "rena", meiFolder("rena"), "Random Outfits", exprOriginal)
+		})
+		return out
+	}
+
+	one, eight := picks(42, 1), picks(42, 8)
+	for i, key := range keys {
+		if !slices.Contains(spriteSets[:8], one[i].variant) {
+			t.Errorf("%s dressed in %q, which isn't installed", key, one[i].variant)
+		}
+		if one[i].variant != eight[i].variant || one[i].expression != eight[i].expression {
+			t.Errorf("%s: %+v with one worker, %+v with eight", key, one[i], eight[i])
+		}
+	}
+
+	other := picks(43, 8)
+	if slices.EqualFunc(one, other, func(a, b spritePick) bool { return a.variant == b.variant }) {
+		t.Error("a different seed picked the same outfit for every sprite")
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !linux && !darwin
+
+package main
+
+import "errors"
+
+func reflink(src, dst string) error {
+	return errors.ErrUnsupported
+}
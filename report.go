@@ -0,0 +1,128 @@
+package main
+
+import (
+	"html/template"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// ReportPair is one game sprite shown before and after the last run
+type ReportPair struct {
+	RunResult
+	Before template.URL
+	After  template.URL
+}
+
+// ReportCostume groups a character's sprites by game costume
+type ReportCostume struct {
+	Name  string
+	Pairs []ReportPair
+}
+
+type ReportCharacter struct {
+	Name     string
+	Costumes []ReportCostume
+}
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Higurashi Randomizer Comparison</title>
+<style>
+body { background: #1b1b22; color: #eee; font-family: sans-serif; }
+.filters { position: sticky; top: 0; background: #1b1b22; padding: 8px 0; }
+.grid { display: grid; grid-template-columns: repeat(auto-fill, minmax(300px, 1fr)); gap: 12px; }
+.pair { background: #2a2a35; border-radius: 6px; padding: 8px; }
+.pair.failed { outline: 2px solid #d55; }
+.pair.fallback { outline: 2px solid #db5; }
+.images { display: flex; gap: 4px; }
+.images img { width: 50%; height: 200px; object-fit: contain; }
+.key { font-weight: bold; }
+.detail { color: #aaa; font-size: 0.9em; }
+body.only-failed .pair:not(.failed), body.only-fallback .pair:not(.fallback),
+body.only-failed.only-fallback .pair:not(.failed):not(.fallback) { display: none; }
+body.only-failed.only-fallback .pair.failed, body.only-failed.only-fallback .pair.fallback { display: block; }
+</style>
+</head>
+<body>
+<h1>Before / After</h1>
+<p class="detail">{{.Time.Format "2006-01-02 15:04"}} · {{.SpritePath}}</p>
+<div class="filters">
+<label><input type="checkbox" onchange="document.body.classList.toggle('only-failed', this.checked)"> Failures only</label>
+<label><input type="checkbox" onchange="document.body.classList.toggle('only-fallback', this.checked)"> Fallbacks only</label>
+</div>
+{{range .Characters}}<h2>{{.Name}}</h2>
+{{range .Costumes}}<h3>{{.Name}}</h3>
+<div class="grid">
+{{range .Pairs}}<div class="pair {{.Status}}">
+<div class="images"><img src="{{.Before}}" alt="before"><img src="{{.After}}" alt="after"></div>
+<div class="key">{{.Key}}</div>
+<div class="detail">{{.Status}}{{if .Variant}} · {{.Variant}}{{end}}{{if .Expression}} · {{.Expression}}{{end}}{{if ne .Used .Expression}} → {{.Used}}{{end}}</div>
+{{if .Error}}<div class="detail">{{.Error}}</div>{{end}}
+</div>
+{{end}}</div>
+{{end}}{{end}}
+</body>
+</html>
+`))
+
+// Returns a file:// URL the report can load a sprite from
+func fileURL(path string) template.URL {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(path)}
+	if u.Path[0] != '/' {
+		u.Path = "/" + u.Path
+	}
+	return template.URL(u.String())
+}
+
+// Groups a run's results by character and then costume, both sorted
+func groupResults(run RunLog) []ReportCharacter {
+	backupDir := filepath.Join(filepath.Dir(run.SpritePath), "sprite_backup")
+	grouped := make(map[string]map[string][]ReportPair)
+	for _, res := range run.Results {
+		if grouped[res.Character] == nil {
+			grouped[res.Character] = make(map[string][]ReportPair)
+		}
+		grouped[res.Character][res.Costume] = append(grouped[res.Character][res.Costume], ReportPair{
+			RunResult: res,
+			Before:    fileURL(filepath.Join(backupDir, res.Key+".png")),
+			After:     fileURL(filepath.Join(run.SpritePath, res.Key+".png")),
+		})
+	}
+
+	var characters []ReportCharacter
+	for name, costumes := range grouped {
+		c := ReportCharacter{Name: name}
+		for costume, pairs := range costumes {
+			sort.Slice(pairs, func(i, j int) bool { return pairs[i].Key < pairs[j].Key })
+			c.Costumes = append(c.Costumes, ReportCostume{Name: costume, Pairs: pairs})
+		}
+		sort.Slice(c.Costumes, func(i, j int) bool { return c.Costumes[i].Name < c.Costumes[j].Name })
+		characters = append(characters, c)
+	}
+	sort.Slice(characters, func(i, j int) bool { return characters[i].Name < characters[j].Name })
+	return characters
+}
+
+// Writes an HTML report pairing each original sprite from the backup
+// with what the last run put in the game
+func exportComparison(run RunLog, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return reportTemplate.Execute(f, struct {
+		RunLog
+		Characters []ReportCharacter
+	}{run, groupResults(run)})
+}
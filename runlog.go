@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"time"
+)
+
+// what happened to one game sprite during a run
+const (
+	statusReplaced = "replaced" // drawn with the face that was asked for
+	statusFallback = "fallback" // drawn with a fallback face from the taxonomy
+	statusKept     = "kept"     // left as (or put back to) the original
+	statusFailed   = "failed"   // couldn't be read, processed or written
+)
+
+// RunResult records how one game sprite was replaced
+type RunResult struct {
+	Key        string `json:"key"`
+	Character  string `json:"character"`
+	Costume    string `json:"costume"`
+	Variant    string `json:"variant,omitempty"`
+	Expression string `json:"expression,omitempty"` // face that was asked for
+	Used       string `json:"used,omitempty"`       // face that was drawn
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+}
+
+// RunLog is the record of the last randomization, kept next to config.json
+type RunLog struct {
+	Time       time.Time   `json:"time"`
+	SpritePath string      `json:"sprite_path"`
+	Seed       int64       `json:"seed"` // gives the same picks again for the same selections
+	Results    []RunResult `json:"results"`
+}
+
+const runLogFile = "last-run.json"
+
+// Returns the game costume a key belongs to: re1a_def_a1_0 → re1a
+func spriteCostume(key string) string {
+	if i := strings.Index(key, "_"); i != -1 {
+		return key[:i]
+	}
+	return key
+}
+
+func saveRunLog(path string, run RunLog) error {
+	data, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func loadRunLog(path string) (RunLog, error) {
+	var run RunLog
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return run, err
+	}
+	err = json.Unmarshal(data, &run)
+	return run, err
+}
+
+// Counts the results with each status
+func (r RunLog) Counts() map[string]int {
+	counts := make(map[string]int)
+	for _, res := range r.Results {
+		counts[res.Status]++
+	}
+	return counts
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ScriptUsage is the set of sprites a chapter's game scripts draw
+type ScriptUsage struct {
+	Chapter string
+	Scripts int
+	Used    map[string][]string // sprite name → scripts that draw it
+}
+
+// DrawBustshot( 1, "sprite/re1a_def_a1_0", ...) and friends name the sprite directly
+var bustshotCall = regexp.MustCompile(`\b(?:DrawBustshot|DrawBustshotWithFiltering|DrawSprite|DrawSpriteWithFiltering|ChangeBustshot)\s*\(\s*-?\d+\s*,\s*"([^"]+)"`)
+
+// ModDrawCharacter(1, 2, "sprite/re1a_", "def_a1_", ...) splits the name into
+// a prefix and an expression, leaving off the mouth frame for lip sync
+var modDrawCall = regexp.MustCompile(`\bModDrawCharacter(?:WithFiltering)?\s*\(\s*-?\d+\s*,\s*-?\d+\s*,\s*"([^"]*)"\s*,\s*"([^"]*)"`)
+
+// Returns the script folders 07th-Mod ships next to CGAlt/sprite
+func scriptDirs(spritePath string) []string {
+	assets := filepath.Dir(filepath.Dir(spritePath))
+	return []string{
+		filepath.Join(assets, "Scripts"),
+		filepath.Join(assets, "Update"),
+	}
+}
+
+// Scans the game scripts for every sprite they draw
+func ScanScripts(spritePath, chapter string) (ScriptUsage, error) {
+	usage := ScriptUsage{Chapter: chapter, Used: make(map[string][]string)}
+	found := false
+
+	for _, dir := range scriptDirs(spritePath) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		found = true
+
+		for _, e := range entries {
+			if e.IsDir() || filepath.Ext(e.Name()) != ".txt" {
+				continue
+			}
+			data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+			if err != nil {
+				return usage, err
+			}
+			usage.Scripts++
+
+			for _, name := range scriptSprites(string(data)) {
+				usage.Used[name] = appendUnique(usage.Used[name], e.Name())
+			}
+		}
+	}
+
+	if !found {
+		return usage, fmt.Errorf("no Scripts or Update folder next to %s", spritePath)
+	}
+	return usage, nil
+}
+
+// Extracts the sprite names drawn by one script
+func scriptSprites(script string) []string {
+	var names []string
+
+	for _, m := range bustshotCall.FindAllStringSubmatch(script, -1) {
+		if name, ok := spriteName(m[1]); ok {
+			names = append(names, name)
+		}
+	}
+
+	for _, m := range modDrawCall.FindAllStringSubmatch(script, -1) {
+		name, ok := spriteName(m[1] + m[2])
+		if !ok {
+			continue
+		}
+		// the engine appends the mouth frame itself, so count every
+		// frame we know of for this pose
+		frames := mouthFrames(name)
+		if len(frames) == 0 {
+			frames = []string{name + "0"}
+		}
+		names = append(names, frames...)
+	}
+
+	return names
+}
+
+// Strips the sprite/ or portrait/ folder from a script path, skipping
+// anything drawn from other folders (backgrounds, effects)
+func spriteName(path string) (string, bool) {
+	path = strings.ReplaceAll(path, "\\", "/")
+	dir, name := "", path
+	if i := strings.LastIndex(path, "/"); i != -1 {
+		dir, name = path[:i], path[i+1:]
+	}
+	switch strings.ToLower(dir) {
+	case "", "sprite", "portrait":
+		return name, name != ""
+	}
+	return "", false
+}
+
+// Returns the mapped keys that are mouth frames of the given pose prefix
+func mouthFrames(prefix string) []string {
+	var frames []string
+	for _, f := range []string{"0", "1", "2"} {
+		if _, ok := RawGameSprites[prefix+f]; ok {
+			frames = append(frames, prefix+f)
+		}
+	}
+	return frames
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, v := range list {
+		if v == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+// Sprites the scripts draw that have no entry in RawGameSprites
+func (u ScriptUsage) Unmapped() []string {
+	var names []string
+	for name := range u.Used {
+		if _, ok := RawGameSprites[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Sprites annotated as first appearing in this chapter that its scripts never draw
+func (u ScriptUsage) Unused() []string {
+	var names []string
+	for key := range RawGameSprites {
+		if SpriteChapter(key) != u.Chapter {
+			continue
+		}
+		if _, ok := u.Used[key]; !ok {
+			names = append(names, key)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Writes the coverage report next to the other text reports
+func (u ScriptUsage) WriteReport(path string) error {
+	var b strings.Builder
+
+	unmapped := u.Unmapped()
+	unused := u.Unused()
+
+	fmt.Fprintf(&b, "Chapter: %s\n", u.Chapter)
+	fmt.Fprintf(&b, "Scripts scanned: %d\n", u.Scripts)
+	fmt.Fprintf(&b, "Sprites used: %d\n\n", len(u.Used))
+
+	fmt.Fprintf(&b, "Used but unmapped (%d):\n", len(unmapped))
+	for _, name := range unmapped {
+		fmt.Fprintf(&b, "%s (%s)\n", name, strings.Join(u.Used[name], ", "))
+	}
+
+	fmt.Fprintf(&b, "\n-------------\n\nMapped but unused (%d):\n", len(unused))
+	for _, name := range unused {
+		fmt.Fprintln(&b, name)
+	}
+
+	return os.WriteFile(path, []byte(b.String()), 0644)
+}
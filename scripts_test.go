@@ -0,0 +1,28 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestScriptSprites(t *testing.T) {
+	tests := []struct {
+		script string
+		want   []string
+	}{
+		{`DrawBustshot( 1, "sprite/re1a_def_a1_0", "", "", 0, 0, 0, FALSE, 0, 0, 0, 0, 0, 0, 0, 20, TRUE );`, []string{"re1a_def_a1_0"}},
+		{`DrawSpriteWithFiltering(2, "portrait\me3_ikari_a1_0", "", 0, 0);`, []string{"me3_ikari_a1_0"}},
+		{`ChangeBustshot( -1, "me3_majime_a1_0", 200, TRUE );`, []string{"me3_majime_a1_0"}},
+		{`DrawBustshot( 1, "background/mura1", "", "", 0, 0 );`, nil},
+		// lip synced: every frame of the pose counts
+		{`ModDrawCharacter(1, 2, "sprite/me3_", "huteki_a1_", "0", 160, 0, 0, FALSE, 0, 0, 0, 0, 0, 0, 0, 20, TRUE);`,
+			[]string{"me3_huteki_a1_0", "me3_huteki_a1_1", "me3_huteki_a1_2"}},
+		{`ModDrawCharacterWithFiltering(1, 2, "sprite/zz_", "unknown_", "1", 0);`, []string{"zz_unknown_0"}},
+		{`OutputLine(NULL, "DrawBustshot( 1, is only text", NULL, "", Line_Normal);`, nil},
+	}
+	for _, tt := range tests {
+		if got := scriptSprites(tt.script); !slices.Equal(got, tt.want) {
+			t.Errorf("scriptSprites(%s) = %v, want %v", tt.script, got, tt.want)
+		}
+	}
+}
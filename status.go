@@ -0,0 +1,204 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// what the sprite folder the game loads holds
+const (
+	installVanilla    = "Vanilla"
+	installRandomized = "Randomized"
+	installPartial    = "Partially modified"
+	installUpdated    = "Updated by the game"
+)
+
+// InstallStatus compares the sprite folder against the backup and the
+// manifest the last run left
+type InstallStatus struct {
+	State      string
+	Vanilla    int      // sprites matching the backup
+	Randomized int      // sprites matching the last run and not the backup
+	Stale      int      // sprites the last run replaced that are vanilla again
+	Changed    []string // sprites matching neither, e.g. replaced by an update
+	New        []string // sprites the backup doesn't have
+	Missing    []string // backed up sprites no longer in the folder
+	HasBackup  bool
+	HasRun     bool
+	Overlay    bool // the randomized overlay folder is in use
+}
+
+// Hashes the sprite folder and sorts every sprite by where its content
+// comes from
+func detectInstall(spriteDir string) (InstallStatus, error) {
+	status := InstallStatus{Overlay: overlayFor(spriteDir).Active()}
+
+	current, err := hashSprites(spriteDir)
+	if err != nil {
+		return status, err
+	}
+	backupDir := filepath.Join(filepath.Dir(spriteDir), "sprite_backup")
+	backup := make(map[string]string)
+	if exists(backupDir) {
+		status.HasBackup = true
+		if backup, err = hashSprites(backupDir); err != nil {
+			return status, err
+		}
+	}
+	last, hasRun := loadHistory(historyDir(spriteDir)).LastRun()
+	status.HasRun = hasRun
+
+	for key, sum := range current {
+		original, backedUp := backup[key]
+		randomized := hasRun && last.Files[key] != "" && last.Files[key] != original
+		switch {
+		case backedUp && sum == original:
+			status.Vanilla++
+			if randomized {
+				status.Stale++
+			}
+		case randomized && sum == last.Files[key]:
+			status.Randomized++
+		case !backedUp && status.HasBackup:
+			status.New = append(status.New, key)
+		case !status.HasBackup && (!hasRun || sum == last.Files[key]):
+			// nothing to compare with: take the folder as the game shipped it
+			status.Vanilla++
+		default:
+			status.Changed = append(status.Changed, key)
+		}
+	}
+	for key := range backup {
+		if _, ok := current[key]; !ok {
+			status.Missing = append(status.Missing, key)
+		}
+	}
+	sort.Strings(status.Changed)
+	sort.Strings(status.New)
+	sort.Strings(status.Missing)
+
+	switch {
+	case len(status.Changed) > 0 || len(status.New) > 0:
+		status.State = installUpdated
+	case status.Randomized == 0:
+		status.State = installVanilla
+	case status.Stale > 0:
+		status.State = installPartial
+	default:
+		status.State = installRandomized
+	}
+	return status, nil
+}
+
+// Returns what the player should do next
+func (s InstallStatus) Recommendations() []string {
+	var recs []string
+	switch s.State {
+	case installVanilla:
+		recs = append(recs, "Randomize to apply your selections.")
+	case installRandomized:
+		recs = append(recs, "Nothing to do. Restore Original Sprites or Undo Last Randomize to go back.")
+	case installPartial:
+		recs = append(recs, fmt.Sprintf("%d randomized sprites are vanilla again. Randomize again, or Restore Original Sprites for a clean game.", s.Stale))
+	case installUpdated:
+		recs = append(recs, fmt.Sprintf("The game changed %d sprites and added %d since the backup.", len(s.Changed), len(s.New)))
+		recs = append(recs, "Press r to take the updated sprites as the new originals and re-apply your selections with the last run's seed.")
+	}
+	if !s.HasBackup {
+		recs = append(recs, "There is no backup yet; the first randomize makes one.")
+	}
+	if len(s.Missing) > 0 {
+		recs = append(recs, fmt.Sprintf("%d backed up sprites are missing from the game. Restore Original Sprites puts them back.", len(s.Missing)))
+	}
+	return recs
+}
+
+func (s InstallStatus) View() string {
+	out := "Install Status\n\n"
+	out += "State: " + s.State + "\n"
+	if s.Overlay {
+		out += "The randomized overlay folder is in use.\n"
+	}
+	out += fmt.Sprintf("\n%d vanilla, %d randomized, %d changed, %d new, %d missing\n",
+		s.Vanilla, s.Randomized, len(s.Changed), len(s.New), len(s.Missing))
+
+	// a few examples are enough to tell what an update touched
+	for _, list := range []struct {
+		name string
+		keys []string
+	}{{"Changed", s.Changed}, {"New", s.New}, {"Missing", s.Missing}} {
+		if len(list.keys) == 0 {
+			continue
+		}
+		shown := list.keys
+		if len(shown) > 5 {
+			shown = shown[:5]
+		}
+		out += fmt.Sprintf("%s: %v", list.name, shown)
+		if len(list.keys) > len(shown) {
+			out += fmt.Sprintf(" and %d more", len(list.keys)-len(shown))
+		}
+		out += "\n"
+	}
+
+	out += "\n"
+	for _, r := range s.Recommendations() {
+		out += "• " + r + "\n"
+	}
+	return out + "\nPress q to return.\n"
+}
+
+// Copies sprites a game update changed or added into the backup, and into
+// the vanilla overlay folder while the randomized one is in use, so they
+// are the originals from now on
+func refreshBackup(spriteDir string, keys []string) error {
+	targets := []string{filepath.Join(filepath.Dir(spriteDir), "sprite_backup")}
+	if originals := overlayFor(spriteDir).Originals(); originals != spriteDir {
+		targets = append(targets, originals)
+	}
+	for _, key := range keys {
+		src := filepath.Join(spriteDir, key+".png")
+		for _, target := range targets {
+			dst := filepath.Join(target, key+".png")
+			if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+				return err
+			}
+			if _, err := deployFile(src, dst, false); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Takes the sprites a game update changed or added as the new
+// originals, so a run fits onto and keeps the updated files rather than
+// the stale backup. Returns how many sprites were taken.
+func refreshAfterUpdate(spriteDir string) (int, error) {
+	status, err := detectInstall(spriteDir)
+	if err != nil || status.State != installUpdated {
+		return 0, err
+	}
+	updated := append(status.Changed, status.New...)
+	return len(updated), refreshBackup(spriteDir, updated)
+}
+
+// Randomizes again with the seed of the last run after a game update,
+// so everything the update didn't touch comes out as it was. The run
+// itself takes the updated sprites as originals.
+func (m model) reapplyAfterUpdate() (tea.Model, tea.Cmd) {
+	return m.randomizeSprites(lastSeed(m.spritePath))
+}
+
+// Returns the seed of the last run, or a new one if there was none
+func lastSeed(spriteDir string) int64 {
+	if last, ok := loadHistory(historyDir(spriteDir)).LastRun(); ok {
+		return last.Run.Seed
+	}
+	return time.Now().UnixNano()
+}
@@ -0,0 +1,57 @@
+package main
+
+import "math/rand"
+
+// option in the swap menus that gives a character back their own sprites
+const ownSprites = "Own Sprites"
+
+// Returns the character whose Mei art is drawn for the given one
+func swapSource(swaps map[string]string, character string) string {
+	if source, ok := swaps[character]; ok && source != "" {
+		return source
+	}
+	return character
+}
+
+// Returns a random permutation of the cast: every character draws with
+// somebody's sprites, and nobody's sprites are used twice
+func shuffleSwaps(characters []string) map[string]string {
+	swaps := make(map[string]string)
+	for i, j := range rand.Perm(len(characters)) {
+		if i != j {
+			swaps[characters[i]] = characters[j]
+		}
+	}
+	return swaps
+}
+
+// Returns swaps that draw the whole cast with one character's sprites
+func everyoneAs(characters []string, source string) map[string]string {
+	swaps := make(map[string]string)
+	for _, c := range characters {
+		if c != source {
+			swaps[c] = source
+		}
+	}
+	return swaps
+}
+
+// Options for the per-character "Use Sprites Of" menu
+func swapOptions(character string) []string {
+	opts := []string{ownSprites}
+	for _, c := range spriteChoices {
+		if c != character {
+			opts = append(opts, c)
+		}
+	}
+	return opts
+}
+
+// Options for the main menu's cast-wide swap menu
+func castSwapOptions() []string {
+	opts := []string{"Shuffle All", "Clear All"}
+	for _, c := range spriteChoices {
+		opts = append(opts, "Everyone as "+c)
+	}
+	return opts
+}
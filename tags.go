@@ -0,0 +1,56 @@
+package main
+
+// OutfitTags is what the content filters know about an outfit
+type OutfitTags struct {
+	Category  string // everyday, seasonal, crossover or costume
+	Season    string // summer, winter, halloween, christmas, new year or ""
+	Age       string // child, teen, adult or "" for the usual age
+	Rating    string // general or suggestive
+	Canonical bool   // worn in the original game
+}
+
+// tags of an outfit outfitTags doesn't list, such as a variant folder
+// the catalogue doesn't know yet
+var unknownOutfitTags = OutfitTags{Category: "costume", Rating: "general"}
+
+// Returns the tags of an outfit from outfitTags
+func (o Outfit) Tags() OutfitTags {
+	if t, ok := outfitTags[o.Name]; ok {
+		return t
+	}
+	return unknownOutfitTags
+}
+
+// content filters, toggled from the main menu
+const (
+	filterFamilyFriendly = "Family-Friendly"
+	filterNoAgeVariants  = "No Age Variants"
+	filterCanonicalOnly  = "Only Canonical Outfits"
+)
+
+var contentFilters = []string{filterFamilyFriendly, filterNoAgeVariants, filterCanonicalOnly}
+
+// Reports whether an outfit passes every enabled filter
+func (o Outfit) Allowed(filters map[string]bool) bool {
+	t := o.Tags()
+	if filters[filterFamilyFriendly] && t.Rating != "general" {
+		return false
+	}
+	if filters[filterNoAgeVariants] && t.Age != "" {
+		return false
+	}
+	if filters[filterCanonicalOnly] && !t.Canonical {
+		return false
+	}
+	return true
+}
+
+func filterOutfits(outfits []Outfit, filters map[string]bool) []Outfit {
+	var allowed []Outfit
+	for _, o := range outfits {
+		if o.Allowed(filters) {
+			allowed = append(allowed, o)
+		}
+	}
+	return allowed
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// EmotionDef describes one face a sprite source can show
+type EmotionDef struct {
+	Name     string `json:"name"`
+	Family   string `json:"family"`
+	Fallback string `json:"fallback,omitempty"` // closest face to use when a pack lacks this one
+}
+
+// the seven faces every Mei pack has
+var BaseEmotions = []EmotionDef{
+	{Name: "normal", Family: "calm"},
+	{Name: "smile", Family: "calm"},
+	{Name: "fuan", Family: "uneasy"},
+	{Name: "odoroki", Family: "uneasy"},
+	{Name: "sinken", Family: "serious"},
+	{Name: "futeki", Family: "serious"},
+	{Name: "L5", Family: "serious"},
+}
+
+// faces the game uses that only some packs draw, each falling back to a base one
+var ExtendedEmotions = []EmotionDef{
+	{Name: "ikari", Family: "serious", Fallback: "sinken"},
+	{Name: "naki", Family: "uneasy", Fallback: "odoroki"},
+	{Name: "komaru", Family: "uneasy", Fallback: "fuan"},
+	{Name: "tere", Family: "uneasy", Fallback: "fuan"},
+	{Name: "kanashii", Family: "uneasy", Fallback: "fuan"},
+}
+
+// Taxonomy is emotion name → definition for one sprite source
+type Taxonomy map[string]EmotionDef
+
+// taxonomyFile is what a pack drops next to its variant folders to
+// declare extra faces: sprites/mei/<folder>/expressions.json
+type taxonomyFile struct {
+	Emotions []EmotionDef `json:"emotions"`
+}
+
+var (
+	taxonomyMu    sync.Mutex
+	taxonomyCache = make(map[string]Taxonomy)
+)
+
+// Forgets the loaded taxonomies so expressions.json edits are read again
+func ResetTaxonomy() {
+	taxonomyMu.Lock()
+	defer taxonomyMu.Unlock()
+	taxonomyCache = make(map[string]Taxonomy)
+}
+
+func DefaultTaxonomy() Taxonomy {
+	t := make(Taxonomy)
+	for _, e := range BaseEmotions {
+		t[e.Name] = e
+	}
+	for _, e := range ExtendedEmotions {
+		t[e.Name] = e
+	}
+	return t
+}
+
+// Returns the taxonomy for a Mei folder: the defaults, then anything
+// declared pack-wide in sprites/mei/expressions.json, then anything the
+// character folder declares
+func LoadTaxonomy(folder string) Taxonomy {
+	taxonomyMu.Lock()
+	defer taxonomyMu.Unlock()
+
+	if t, ok := taxonomyCache[folder]; ok {
+		return t
+	}
+
+	t := DefaultTaxonomy()
+	for _, path := range []string{
+		filepath.Join("sprites", "mei", "expressions.json"),
+		filepath.Join("sprites", "mei", folder, "expressions.json"),
+	} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var f taxonomyFile
+		if err := json.Unmarshal(data, &f); err != nil {
+			log.Printf("Could not parse %s: %v", path, err)
+			continue
+		}
+		for _, e := range f.Emotions {
+			if e.Name != "" {
+				t[e.Name] = e
+			}
+		}
+	}
+
+	taxonomyCache[folder] = t
+	return t
+}
+
+// Returns the family of an emotion, or the emotion itself if it has none
+func (t Taxonomy) Family(emotion string) string {
+	if e, ok := t[emotion]; ok && e.Family != "" {
+		return e.Family
+	}
+	return emotion
+}
+
+// Returns the expression followed by the same blush and state on each
+// fallback face in turn, ending at a base emotion
+func (t Taxonomy) Fallbacks(expression string) []string {
+	list := []string{expression}
+
+	e, ok := ParseExpression(expression)
+	if !ok {
+		return list
+	}
+
+	seen := map[string]bool{e.Emotion: true}
+	for {
+		def, ok := t[e.Emotion]
+		if !ok || def.Fallback == "" || seen[def.Fallback] {
+			return list
+		}
+		seen[def.Fallback] = true
+		e.Emotion = def.Fallback
+		list = append(list, e.String())
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTaxonomyFallbacks(t *testing.T) {
+	tests := []struct {
+		taxonomy   Taxonomy
+		expression string
+		want       []string
+	}{
+		{DefaultTaxonomy(), "ikari_blush_open", []string{"ikari_blush_open", "sinken_blush_open"}},
+		{DefaultTaxonomy(), "naki_half", []string{"naki_half", "odoroki_half"}},
+		{DefaultTaxonomy(), "smile_open", []string{"smile_open"}},
+		{DefaultTaxonomy(), "weird", []string{"weird"}},
+		{Taxonomy{"a": {Fallback: "b"}, "b": {Fallback: "c"}}, "a_close", []string{"a_close", "b_close", "c_close"}},
+		// a cycle stops once it comes back around
+		{Taxonomy{"a": {Fallback: "b"}, "b": {Fallback: "a"}}, "a_open", []string{"a_open", "b_open"}},
+	}
+	for _, tt := range tests {
+		if got := tt.taxonomy.Fallbacks(tt.expression); !slices.Equal(got, tt.want) {
+			t.Errorf("Fallbacks(%q) = %v, want %v", tt.expression, got, tt.want)
+		}
+	}
+}
+
+func TestDrawnEmotion(t *testing.T) {
+	tax := DefaultTaxonomy()
+	tests := []struct {
+		available  []string
+		expression string
+		want       string
+	}{
+		{[]string{"sinken_open", "sinken_blush_open"}, "ikari_open", "sinken"},
+		{[]string{"ikari_open", "sinken_open"}, "ikari_open", "ikari"},
+		{[]string{"smile_open"}, "komaru_close", "komaru"},
+		{[]string{"fuan_open"}, "komaru_close", "fuan"},
+	}
+	for _, tt := range tests {
+		if got := drawnEmotion(tax, tt.available, tt.expression); got != tt.want {
+			t.Errorf("drawnEmotion(%v, %q) = %q, want %q", tt.available, tt.expression, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// what a theme does for characters without the themed outfit
+const (
+	themeKeepCurrent = "Keep Current"
+	themeBestMatch   = "Best Match"
+	themeRandom      = "Random Outfits"
+)
+
+var themeFallbacks = []string{themeKeepCurrent, themeBestMatch, themeRandom}
+
+// Strips the qualifier from an outfit name: Dark Awakening (Casual) → Dark Awakening
+func outfitBase(name string) string {
+	if i := strings.Index(name, " ("); i != -1 {
+		return name[:i]
+	}
+	return name
+}
+
+// names too generic to dress characters alike: everyone's Default or
+// Teen outfit is just what they usually wear
+var genericOutfits = map[string]bool{
+	"Default": true,
+	"Teen":    true,
+	"Adult":   true,
+	"Hatless": true,
+}
+
+// Lists the outfit names at least two characters share, either exactly
+// (Outbreak (School)) or by base name (Dark Awakening)
+func CostumeThemes() []string {
+	owners := make(map[string]map[string]bool)
+	add := func(theme, character string) {
+		if owners[theme] == nil {
+			owners[theme] = make(map[string]bool)
+		}
+		owners[theme][character] = true
+	}
+
+	for character, data := range Characters {
+		for _, o := range data.OutfitsMei {
+			add(o.Name, character)
+			add(outfitBase(o.Name), character)
+		}
+	}
+
+	var themes []string
+	for theme, who := range owners {
+		if len(who) >= 2 && !genericOutfits[theme] {
+			themes = append(themes, theme)
+		}
+	}
+	sort.Strings(themes)
+	return themes
+}
+
+// Returns the installed outfit of a character matching the theme,
+// preferring an exact name over a base-name match. Outfits the content
+// filters hide never match.
+func themeOutfit(character, theme string, filters map[string]bool) (Outfit, bool) {
+	outfits := filterOutfits(installedOutfits(character), filters)
+	for _, o := range outfits {
+		if o.Name == theme {
+			return o, true
+		}
+	}
+	for _, o := range outfits {
+		if outfitBase(o.Name) == theme {
+			return o, true
+		}
+	}
+	return Outfit{}, false
+}
+
+// Dresses every character who has the themed outfit in it, applying the
+// fallback to everyone else. Returns how many characters wear the theme.
+func applyTheme(selections map[string]string, theme, fallback string, filters map[string]bool) int {
+	n := 0
+	for _, c := range spriteChoices {
+		if o, ok := themeOutfit(c, theme, filters); ok {
+			selections[c] = fmt.Sprintf("%s (variant: %s)", o.Name, o.SpriteSet)
+			n++
+			continue
+		}
+		switch fallback {
+		case themeBestMatch:
+			selections[c] = "Best Match"
+		case themeRandom:
+			selections[c] = "Random Outfits"
+		}
+	}
+	return n
+}
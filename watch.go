@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// how often watch looks at config.json and the sprite pack; each check
+// only stats the Mei folders the game's sprites are drawn from
+const watchInterval = 5 * time.Second
+
+// fileStamp is what watch compares to tell a file changed
+type fileStamp struct {
+	ModTime time.Time
+	Size    int64
+}
+
+func stampFile(path string) fileStamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{info.ModTime(), info.Size()}
+}
+
+// Returns a stamp for the pack-wide files of the sprite pack and every
+// file under the given Mei folders
+func stampPack(root string, folders map[string]bool) map[string]fileStamp {
+	stamps := make(map[string]fileStamp)
+	entries, _ := os.ReadDir(root)
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		path := filepath.Join(root, e.Name())
+		stamps[path] = stampFile(path)
+	}
+	for folder := range folders {
+		filepath.Walk(filepath.Join(root, folder), func(path string, info os.FileInfo, err error) error {
+			if err == nil && !info.IsDir() {
+				stamps[path] = fileStamp{info.ModTime(), info.Size()}
+			}
+			return nil
+		})
+	}
+	return stamps
+}
+
+// Returns the Mei folders the game's sprites are drawn from
+func watchedFolders(swaps map[string]string) map[string]bool {
+	folders := make(map[string]bool)
+	for key := range RawGameSprites {
+		folders[drawnFrom(key, swaps)] = true
+	}
+	return folders
+}
+
+// Returns the Mei folders with files added, removed or changed between
+// two scans; "" stands for a pack-wide file like sprites/mei/expressions.json
+func changedFolders(root string, old, cur map[string]fileStamp) map[string]bool {
+	folders := make(map[string]bool)
+	mark := func(path string) {
+		rel, _ := filepath.Rel(root, path)
+		folder, _, nested := strings.Cut(filepath.ToSlash(rel), "/")
+		if !nested {
+			folder = ""
+		}
+		folders[folder] = true
+	}
+	for path, stamp := range cur {
+		if old[path] != stamp {
+			mark(path)
+		}
+	}
+	for path := range old {
+		if _, ok := cur[path]; !ok {
+			mark(path)
+		}
+	}
+	return folders
+}
+
+// Returns the characters whose sprites come out differently under the
+// new config; nil means everyone
+func affectedCharacters(old, cur Config) map[string]bool {
+	if old.SpritePath != cur.SpritePath || old.GamePath != cur.GamePath || old.Output != cur.Output ||
+		!maps.Equal(old.Filters, cur.Filters) || !slices.Equal(old.Effects[""], cur.Effects[""]) {
+		return nil
+	}
+
+	affected := make(map[string]bool)
+	for _, c := range spriteChoices {
+		if old.Selections[c] != cur.Selections[c] || old.Expressions[c] != cur.Expressions[c] ||
+			swapSource(old.Swaps, c) != swapSource(cur.Swaps, c) ||
+			!slices.Equal(effectsFor(old.Effects, c), effectsFor(cur.Effects, c)) {
+			affected[c] = true
+		}
+	}
+	for _, calibrations := range []map[string]Calibration{old.Calibrations, cur.Calibrations} {
+		for key := range calibrations {
+			if old.Calibrations[key] != cur.Calibrations[key] {
+				character, _, _ := strings.Cut(key, "/")
+				affected[character] = true
+			}
+		}
+	}
+
+	// a character drawn as someone else changes with them
+	for _, c := range spriteChoices {
+		if affected[swapSource(cur.Swaps, c)] {
+			affected[c] = true
+		}
+	}
+	return affected
+}
+
+// Returns the Mei folder a game sprite is drawn from, following swaps
+func drawnFrom(key string, swaps map[string]string) string {
+	character := GetCharacter(key)
+	if source := swapSource(swaps, character); source != character {
+		return meiFolder(source)
+	}
+	return GetFolder(key)
+}
+
+// Watches config.json and the sprite pack until interrupted, randomizing
+// again the sprites of every character a change affects. Changes are
+// picked up once a check finds nothing new, so an editor saving in
+// several steps causes one run.
+func runWatch() error {
+	cfg, err := readConfig()
+	if err != nil {
+		return fmt.Errorf("could not read config.json: %w", err)
+	}
+	root := filepath.Join("sprites", "mei")
+	PackVariants = ScanSpritePack(root)
+	m := newModel(cfg)
+	if m.spritePath == "" {
+		return errors.New("select a game in the menu first")
+	}
+	cfg = m.config()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	configStamp := stampFile("config.json")
+	watched := watchedFolders(m.swaps)
+	pack := stampPack(root, watched)
+	everyone := false
+	characters := make(map[string]bool)
+	folders := make(map[string]bool)
+
+	// a game update while nobody was watching: the run takes the updated
+	// sprites as originals and lays the last run's picks over them again
+	if status, err := detectInstall(m.spritePath); err == nil && status.State == installUpdated {
+		fmt.Printf("%s The game was updated, randomizing everyone again...\n", time.Now().Format("15:04:05"))
+		fmt.Println(m.runRandomize(ctx, nil, lastSeed(m.spritePath), nil))
+	}
+
+	fmt.Println("Watching config.json and", root, "- Ctrl+C to stop.")
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		changed := false
+		before := watched
+		if stamp := stampFile("config.json"); stamp != configStamp {
+			configStamp, changed = stamp, true
+			// a half-saved file is read again on the next check
+			next, err := readConfig()
+			if err != nil {
+				configStamp = fileStamp{}
+				continue
+			}
+			m = newModel(next)
+			next = m.config()
+			if affected := affectedCharacters(cfg, next); affected == nil {
+				everyone = true
+			} else {
+				maps.Copy(characters, affected)
+			}
+			cfg = next
+			watched = watchedFolders(m.swaps)
+		}
+		if stamps := stampPack(root, watched); !maps.Equal(stamps, pack) {
+			// folders a config change swapped in or out aren't changes of
+			// their own; the config change already counts
+			for folder := range changedFolders(root, pack, stamps) {
+				if folder == "" || before[folder] && watched[folder] {
+					folders[folder] = true
+				}
+			}
+			pack, changed = stamps, true
+		}
+		if changed || (!everyone && len(characters) == 0 && len(folders) == 0) {
+			continue
+		}
+
+		PackVariants = ScanSpritePack(root)
+		ResetTaxonomy()
+		var only func(key string) bool
+		if !everyone && !folders[""] {
+			only = func(key string) bool {
+				return characters[GetCharacter(key)] || folders[drawnFrom(key, m.swaps)]
+			}
+		}
+
+		fmt.Printf("%s Randomizing %s...\n", time.Now().Format("15:04:05"), describeWatchChange(everyone || folders[""], characters, folders))
+		fmt.Println(m.runRandomize(ctx, nil, lastSeed(m.spritePath), only))
+
+		everyone = false
+		characters = make(map[string]bool)
+		folders = make(map[string]bool)
+	}
+}
+
+func describeWatchChange(all bool, characters, folders map[string]bool) string {
+	if all {
+		return "everyone"
+	}
+	var parts []string
+	for _, c := range slices.Sorted(maps.Keys(characters)) {
+		parts = append(parts, c)
+	}
+	for _, f := range slices.Sorted(maps.Keys(folders)) {
+		parts = append(parts, "sprites/mei/"+f)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestAffectedCharacters(t *testing.T) {
+	base := func() Config {
+		return Config{
+			SpritePath:   `C:\Games\Higurashi\CGAlt\sprite`,
+			Selections:   map[string]string{"rena": "Best Match", "keiichi": "Best Match"},
+			Swaps:        map[string]string{},
+			Effects:      map[string][]string{"": {"sepia"}},
+			Calibrations: map[string]Calibration{"rena/v005": {Scale: 1.1}},
+		}
+	}
+	swapped := func() Config {
+		cfg := base()
+		cfg.Swaps["keiichi"] = "rena"
+		return cfg
+	}
+
+	tests := []struct {
+		name   string
+		old    Config
+		change func(cfg *Config)
+		want   []string // nil for everyone
+	}{
+		{"nothing", base(), func(cfg *Config) {}, []string{}},
+		{"sprite path", base(), func(cfg *Config) { cfg.SpritePath = `D:\sprite` }, nil},
+		{"global effects", base(), func(cfg *Config) { cfg.Effects[""] = []string{"grayscale"} }, nil},
+		{"content filter", base(), func(cfg *Config) { cfg.Filters = map[string]bool{filterFamilyFriendly: true} }, nil},
+		{"selection", base(), func(cfg *Config) { cfg.Selections["rena"] = "Random Outfits" }, []string{"rena"}},
+		{"expression mode", base(), func(cfg *Config) { cfg.Expressions["keiichi"] = exprFullyRandom }, []string{"keiichi"}},
+		{"own effects", base(), func(cfg *Config) { cfg.Effects["rena"] = []string{"sepia"} }, []string{}},
+		{"calibration", base(), func(cfg *Config) { cfg.Calibrations["rena/v005"] = Calibration{Scale: 0.9} }, []string{"rena"}},
+		{"new swap", base(), func(cfg *Config) { cfg.Swaps["keiichi"] = "rena" }, []string{"keiichi"}},
+		{"swap source changed", swapped(), func(cfg *Config) { cfg.Selections["rena"] = "Random Outfits" }, []string{"rena", "keiichi"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cur := tt.old
+			cur.Selections = maps.Clone(tt.old.Selections)
+			cur.Expressions = map[string]string{}
+			cur.Swaps = maps.Clone(tt.old.Swaps)
+			cur.Effects = maps.Clone(tt.old.Effects)
+			cur.Calibrations = maps.Clone(tt.old.Calibrations)
+			tt.change(&cur)
+
+			got := affectedCharacters(tt.old, cur)
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("affected %v, want everyone", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("affected everyone, want %v", tt.want)
+			}
+			if names := slices.Sorted(maps.Keys(got)); !slices.Equal(names, slices.Sorted(slices.Values(tt.want))) {
+				t.Errorf("affected %v, want %v", names, tt.want)
+			}
+		})
+	}
+}